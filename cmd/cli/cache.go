@@ -0,0 +1,44 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+
+	"daily_report/internal/config"
+	"daily_report/internal/llm"
+)
+
+// runCache implements the "cache" subcommand
+func runCache(args []string) int {
+	if len(args) == 0 || args[0] != "prune" {
+		fmt.Fprintf(os.Stderr, "Usage: daily_report cache prune [--config FILE] [--all]\n")
+		return 2
+	}
+
+	fs := flag.NewFlagSet("cache prune", flag.ExitOnError)
+	configPath := fs.String("config", "config.yaml", "Path to config file")
+	all := fs.Bool("all", false, "Remove all entries, not only expired ones")
+	fs.Parse(args[1:])
+
+	cfg, err := config.Load(*configPath)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error loading config: %v\n", err)
+		return 1
+	}
+
+	cache, err := llm.NewCache(cfg.Report.LLM.Cache.Dir, cfg.Report.LLM.Cache.TTL)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error opening cache: %v\n", err)
+		return 1
+	}
+
+	removed, err := cache.Prune(*all)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error pruning cache: %v\n", err)
+		return 1
+	}
+
+	fmt.Printf("Removed %d cache entries from %s\n", removed, cache.Dir())
+	return 0
+}
@@ -0,0 +1,208 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"text/tabwriter"
+	"time"
+
+	"daily_report/internal/config"
+	"daily_report/internal/history"
+	"daily_report/internal/report"
+	"daily_report/internal/state"
+	"daily_report/pkg/models"
+)
+
+// historyUsage lists the history subcommands
+const historyUsage = `Usage:
+  daily_report history list [--config FILE]
+  daily_report history show [--config FILE] [--period P] [--format F] [--data] DATE
+  daily_report history diff [--config FILE] [--period P] [--format F] DATE1 DATE2
+`
+
+// runHistory implements the "history" subcommand
+func runHistory(args []string) int {
+	if len(args) == 0 {
+		fmt.Fprint(os.Stderr, historyUsage)
+		return 2
+	}
+
+	fs := flag.NewFlagSet("history "+args[0], flag.ExitOnError)
+	configPath := fs.String("config", "config.yaml", "Path to config file")
+	period := fs.String("period", models.PeriodDay, "Report period of the entries: day, week or month")
+	format := fs.String("format", report.FormatMarkdown, "Output format the report was generated in")
+	showData := fs.Bool("data", false, "Print the stored report data as JSON instead of the rendered report")
+	fs.Parse(args[1:])
+
+	cfg, err := config.Load(*configPath)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error loading config: %v\n", err)
+		return 1
+	}
+	store, err := openHistory(cfg)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error opening history: %v\n", err)
+		return 1
+	}
+
+	switch {
+	case args[0] == "list" && fs.NArg() == 0:
+		return historyList(store, cfg)
+	case args[0] == "show" && fs.NArg() == 1:
+		return historyShow(store, cfg, fs.Arg(0), *period, *format, *showData)
+	case args[0] == "diff" && fs.NArg() == 2:
+		return historyDiff(store, cfg, fs.Arg(0), fs.Arg(1), *period, *format)
+	default:
+		fmt.Fprint(os.Stderr, historyUsage)
+		return 2
+	}
+}
+
+// historyList prints the stored reports of the profile
+func historyList(store *history.Store, cfg *config.Config) int {
+	loc, err := time.LoadLocation(cfg.Time.Timezone)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return 1
+	}
+	entries, err := store.List(cfg.State.Profile)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error reading history: %v\n", err)
+		return 1
+	}
+	if len(entries) == 0 {
+		fmt.Printf("No reports in %s\n", store.Dir())
+		return 0
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "DATE\tPERIOD\tRANGE\tFORMAT\tGENERATED")
+	for _, e := range entries {
+		rng := e.Range
+		if rng == "" {
+			rng = "-"
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n", e.Date, e.Period, rng, e.Format, e.GeneratedAt.In(loc).Format("2006-01-02 15:04"))
+	}
+	w.Flush()
+	return 0
+}
+
+// historyShow prints a stored report
+func historyShow(store *history.Store, cfg *config.Config, date, period, format string, showData bool) int {
+	entry, err := getHistoryEntry(store, cfg, date, period, format)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return 1
+	}
+
+	if showData {
+		out, err := json.MarshalIndent(entry.Data, "", "  ")
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error encoding report data: %v\n", err)
+			return 1
+		}
+		fmt.Println(string(out))
+		return 0
+	}
+	fmt.Print(entry.Output)
+	return 0
+}
+
+// historyDiff prints what changed between two stored reports
+func historyDiff(store *history.Store, cfg *config.Config, date1, date2, period, format string) int {
+	older, err := getHistoryEntry(store, cfg, date1, period, format)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return 1
+	}
+	newer, err := getHistoryEntry(store, cfg, date2, period, format)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return 1
+	}
+
+	fmt.Print(history.Compare(older.Data, newer.Data).Format(older.Date, newer.Date))
+	return 0
+}
+
+// getHistoryEntry resolves a date expression such as 2026-10-19 or yesterday and loads its report
+func getHistoryEntry(store *history.Store, cfg *config.Config, expr, period, format string) (*history.Entry, error) {
+	parser, err := newRangeParser(cfg)
+	if err != nil {
+		return nil, err
+	}
+	start, _, err := parser.Parse(expr)
+	if err != nil {
+		return nil, err
+	}
+	// Entries are keyed by the start of their period, e.g. the Monday of a week
+	start, _, err = parser.PeriodRange(period, start)
+	if err != nil {
+		return nil, err
+	}
+	return store.Get(cfg.State.Profile, start.Format("2006-01-02"), period, format)
+}
+
+// openHistory opens the configured history store
+func openHistory(cfg *config.Config) (*history.Store, error) {
+	dir := cfg.History.Dir
+	if dir == "" {
+		base := cfg.State.Dir
+		if base == "" {
+			var err error
+			base, err = state.DefaultDir()
+			if err != nil {
+				return nil, err
+			}
+		}
+		dir = filepath.Join(base, "history")
+	}
+	return history.Open(dir, cfg.History.MaxAge, cfg.History.MaxEntries), nil
+}
+
+// historyRange returns the range key of a report that does not cover exactly one day, week or
+// month, such as --date since-last or --from/--to, so that it does not replace the whole period
+func historyRange(cfg *config.Config, data *models.ReportData) (string, error) {
+	parser, err := newRangeParser(cfg)
+	if err != nil {
+		return "", err
+	}
+	start, end, err := parser.PeriodRange(data.Period, data.StartTime)
+	if err != nil {
+		return "", err
+	}
+	if start.Equal(data.StartTime) && end.Equal(data.EndTime) {
+		return "", nil
+	}
+	return history.RangeKey(data.StartTime.In(parser.Location), data.EndTime), nil
+}
+
+// saveHistory stores a generated report unless history is disabled; failures only warn
+func saveHistory(cfg *config.Config, data *models.ReportData, format, output string) {
+	if cfg.History.Disabled {
+		return
+	}
+
+	entry := history.Entry{
+		Profile: cfg.State.Profile,
+		Date:    history.DateKey(data),
+		Period:  data.Period,
+		Format:  format,
+		Data:    data,
+		Output:  output,
+	}
+	store, err := openHistory(cfg)
+	if err == nil {
+		entry.Range, err = historyRange(cfg, data)
+	}
+	if err == nil {
+		err = store.Save(entry)
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: failed to save report history: %v\n", err)
+	}
+}
@@ -5,32 +5,102 @@ import (
 	"flag"
 	"fmt"
 	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
 
+	"daily_report/internal/aggregate"
+	"daily_report/internal/calendar"
 	"daily_report/internal/collector"
 	"daily_report/internal/config"
+	"daily_report/internal/llm"
+	"daily_report/internal/publish"
 	"daily_report/internal/report"
+	"daily_report/internal/state"
 	"daily_report/internal/timeutil"
 	"daily_report/pkg/models"
 )
 
 func main() {
+	// Dispatch subcommands
+	if len(os.Args) > 1 {
+		switch os.Args[1] {
+		case "cache":
+			os.Exit(runCache(os.Args[2:]))
+		case "template":
+			os.Exit(runTemplate(os.Args[2:]))
+		case "worklog":
+			os.Exit(runWorklog(os.Args[2:]))
+		case "history":
+			os.Exit(runHistory(os.Args[2:]))
+		case "search":
+			os.Exit(runSearch(os.Args[2:]))
+		case "stats":
+			os.Exit(runStats(os.Args[2:]))
+		case "weekly":
+			// "weekly" is shorthand for --period week
+			os.Args = append([]string{os.Args[0], "--period", models.PeriodWeek}, os.Args[2:]...)
+		case "schema":
+			os.Stdout.Write(models.ReportDataSchema)
+			os.Exit(0)
+		}
+	}
+
 	// Parse command line flags
 	configPath := flag.String("config", "config.yaml", "Path to config file")
-	dateRange := flag.String("date", "today", "Date range: today, yesterday, or YYYY-MM-DD,YYYY-MM-DD")
+	dateRange := flag.String("date", "today", "Date or range: today, yesterday, since-last, -3d, last-workday, prev-workday, last-week, this-month, last-monday, 2026-W42, 2026-10, YYYY-MM-DD or START,END (empty END: until now)")
+	from := flag.String("from", "", "Exact range start instead of --date: RFC3339, \"YYYY-MM-DD HH:MM\" or HH:MM (today)")
+	to := flag.String("to", "", "Exact range end for --from, same formats (default: now)")
 	outputPath := flag.String("output", "", "Output file path (default: stdout)")
+	period := flag.String("period", models.PeriodDay, "Report period: day, week (ISO week containing --date) or month")
 	mode := flag.String("mode", "template", "Report mode: template or llm")
-	templatePath := flag.String("template", "", "Path to custom Markdown template file")
+	templatePath := flag.String("template", "", "Custom template: file path, directory or template name (e.g. weekly)")
+	noCache := flag.Bool("no-cache", false, "Bypass the LLM response cache in llm mode")
+	strict := flag.Bool("strict", false, "Fail on template warnings such as missing required sections")
+	format := flag.String("format", report.FormatMarkdown, "Output format: markdown, html, text, json, yaml, confluence, feishu, dingtalk, wecom, slack or teams")
+	style := flag.String("style", "", "Output style: compact is shorthand for --format text")
+	maxChars := flag.Int("max-chars", 0, "Maximum characters of --format text output (0: unlimited)")
+	reportURL := flag.String("report-url", "", "Link to the full report shown as a button on chat cards")
+	publishTo := flag.String("publish", "", "Comma-separated publish target names from config to send the report to")
+	skipEmptyDays := flag.Bool("skip-empty-days", false, "Omit days without items from multi-day reports and --split-days")
+	splitDays := flag.Bool("split-days", false, "With --date since-last, generate one report per day instead of one for the whole gap")
+	inputPath := flag.String("input", "", "Render from previously exported report data (JSON or YAML) instead of collecting")
 	flag.Usage = func() {
 		fmt.Fprintf(flag.CommandLine.Output(), "Daily Report Generator\n\n")
 		fmt.Fprintf(flag.CommandLine.Output(), "Usage:\n")
-		fmt.Fprintf(flag.CommandLine.Output(), "  daily_report [options]\n\n")
+		fmt.Fprintf(flag.CommandLine.Output(), "  daily_report [options]\n")
+		fmt.Fprintf(flag.CommandLine.Output(), "  daily_report weekly [options]        # Same as --period week\n")
+		fmt.Fprintf(flag.CommandLine.Output(), "  daily_report cache prune [--all]\n")
+		fmt.Fprintf(flag.CommandLine.Output(), "  daily_report template check [template...]\n")
+		fmt.Fprintf(flag.CommandLine.Output(), "  daily_report worklog [--date DAY] [--dry-run] [--yes]\n")
+		fmt.Fprintf(flag.CommandLine.Output(), "  daily_report history list|show|diff   # Browse previously generated reports\n")
+		fmt.Fprintf(flag.CommandLine.Output(), "  daily_report search QUERY             # Search items of past reports, e.g. \"login timeout repo:api\"\n")
+		fmt.Fprintf(flag.CommandLine.Output(), "  daily_report stats [--by week] [--csv FILE]  # Activity trends from report history\n")
+		fmt.Fprintf(flag.CommandLine.Output(), "  daily_report schema                   # Print the JSON Schema of --format json\n\n")
 		fmt.Fprintf(flag.CommandLine.Output(), "Options:\n")
 		flag.PrintDefaults()
 		fmt.Fprintf(flag.CommandLine.Output(), "\nExamples:\n")
 		fmt.Fprintf(flag.CommandLine.Output(), "  daily_report                          # Generate today's report\n")
 		fmt.Fprintf(flag.CommandLine.Output(), "  daily_report --date yesterday        # Generate yesterday's report\n")
+		fmt.Fprintf(flag.CommandLine.Output(), "  daily_report --date prev-workday      # Previous workday, skipping weekends and holidays\n")
+		fmt.Fprintf(flag.CommandLine.Output(), "  daily_report --date since-last        # Everything since the last generated report\n")
+		fmt.Fprintf(flag.CommandLine.Output(), "  daily_report --date last-week         # Last week's items in one report\n")
+		fmt.Fprintf(flag.CommandLine.Output(), "  daily_report --date 2026-10-01,      # From Oct 1st until now\n")
+		fmt.Fprintf(flag.CommandLine.Output(), "  daily_report --from 09:00 --to 13:00  # This morning only\n")
+		fmt.Fprintf(flag.CommandLine.Output(), "  daily_report weekly --date 2026-10-12  # Weekly report for the week of that day\n")
+		fmt.Fprintf(flag.CommandLine.Output(), "  daily_report --date 2026-10-12,2026-10-16 --skip-empty-days  # One section per day\n")
 		fmt.Fprintf(flag.CommandLine.Output(), "  daily_report --output report.md      # Save to file\n")
 		fmt.Fprintf(flag.CommandLine.Output(), "  daily_report --template custom.tmpl  # Use custom template\n")
+		fmt.Fprintf(flag.CommandLine.Output(), "  daily_report --template weekly       # Use template by name from search path\n")
+		fmt.Fprintf(flag.CommandLine.Output(), "  daily_report --mode llm --no-cache   # Regenerate with LLM, ignoring cache\n")
+		fmt.Fprintf(flag.CommandLine.Output(), "  daily_report template check me.md    # Validate a custom template\n")
+		fmt.Fprintf(flag.CommandLine.Output(), "  daily_report --format json --output r.json  # Export collected data\n")
+		fmt.Fprintf(flag.CommandLine.Output(), "  daily_report --input r.json                 # Re-render exported data\n")
+		fmt.Fprintf(flag.CommandLine.Output(), "  daily_report --style compact --max-chars 500  # Short summary for chat\n")
+		fmt.Fprintf(flag.CommandLine.Output(), "  daily_report --format feishu                  # Feishu card payloads, one JSON per line\n")
+		fmt.Fprintf(flag.CommandLine.Output(), "  daily_report --format slack                   # Slack Block Kit payloads\n")
+		fmt.Fprintf(flag.CommandLine.Output(), "  daily_report --publish team,slack            # Send to configured chat webhooks\n")
 	}
 	flag.Parse()
 
@@ -46,24 +116,391 @@ func main() {
 		cfg.Report.Mode = *mode
 	}
 
-	// Override template path from command line
+	// Compact style is the plain-text renderer
+	switch *style {
+	case "":
+	case "compact":
+		*format = report.FormatText
+	default:
+		fmt.Fprintf(os.Stderr, "Error: unsupported style %q\n", *style)
+		os.Exit(2)
+	}
+
+	// Override template path from command line; week and month reports default to the weekly template
 	if *templatePath != "" {
 		cfg.Report.TemplatePath = *templatePath
+	} else if *period != models.PeriodDay && cfg.Report.TemplatePath == "" {
+		cfg.Report.TemplatePath = "weekly"
+	}
+
+	if *skipEmptyDays {
+		cfg.Report.SkipEmptyDays = true
+	}
+
+	ctx := context.Background()
+	opts := reportOptions{
+		format:     *format,
+		maxChars:   *maxChars,
+		reportURL:  *reportURL,
+		noCache:    *noCache,
+		strict:     *strict,
+		outputPath: *outputPath,
+		publishTo:  *publishTo,
 	}
 
-	// Parse time range
-	start, end, err := timeutil.ParseTimeRange(*dateRange, cfg.Time.Timezone)
+	// Render previously exported data without collecting
+	if *inputPath != "" {
+		reportData, err := loadReportData(*inputPath)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error loading input: %v\n", err)
+			os.Exit(1)
+		}
+		// Re-rendered data is not a new report and must not replace the stored one
+		opts.noHistory = true
+		os.Exit(runReport(ctx, cfg, reportData, opts))
+	}
+
+	start, end, err := parseRange(cfg, *dateRange, *from, *to, *period)
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "Error parsing time range: %v\n", err)
 		os.Exit(1)
 	}
 
+	// Widen the range to the week or month containing its start
+	if *period != models.PeriodDay {
+		start, end, err = periodRange(cfg, *period, start)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(2)
+		}
+	}
+
+	ranges := []timeutil.Range{{Start: start, End: end}}
+	if *dateRange == sinceLast {
+		ranges, err = sinceLastRanges(cfg, start, end, *splitDays)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+	}
+
+	// The report end only moves past ranges every source was collected for, so that
+	// the next --date since-last covers a failed range again
+	var collectedEnd time.Time
+	collectedAll := true
+	for _, r := range ranges {
+		reportData := collectReportData(ctx, cfg, r.Start, r.End)
+		if failed := failedSources(reportData); len(failed) > 0 {
+			if collectedAll {
+				fmt.Fprintf(os.Stderr, "Warning: collection from %s failed; the report end is not recorded past %s\n",
+					strings.Join(failed, ", "), r.Start.Format("2006-01-02 15:04"))
+			}
+			collectedAll = false
+		} else if collectedAll {
+			collectedEnd = r.End
+		}
+		if *period != models.PeriodDay {
+			reportData.Period = *period
+			reportData.Aggregate = aggregate.Build(reportData)
+		}
+		if len(ranges) > 1 {
+			if cfg.Report.SkipEmptyDays && len(reportData.Items) == 0 {
+				continue
+			}
+			opts.outputPath = splitOutputPath(*outputPath, r.Start)
+		}
+
+		if code := runReport(ctx, cfg, reportData, opts); code != 0 {
+			os.Exit(code)
+		}
+	}
+
+	// Remember where this report stopped for --date since-last
+	if !collectedEnd.IsZero() {
+		recordReportEnd(cfg, collectedEnd)
+	}
+}
+
+// failedSources returns the sorted names of the sources that could not be collected
+func failedSources(data *models.ReportData) []string {
+	var failed []string
+	for name, status := range data.SourceStatus {
+		if !status.Success {
+			failed = append(failed, name)
+		}
+	}
+	sort.Strings(failed)
+	return failed
+}
+
+// reportOptions are the command line options that control rendering and delivery
+type reportOptions struct {
+	format     string
+	maxChars   int
+	reportURL  string
+	noCache    bool
+	strict     bool
+	outputPath string
+	publishTo  string
+	noHistory  bool
+}
+
+// runReport renders the report, writes or prints it and publishes it, returning the exit code
+func runReport(ctx context.Context, cfg *config.Config, reportData *models.ReportData, opts reportOptions) int {
+	var output string
+	var err error
+	switch {
+	case opts.format == report.FormatJSON || opts.format == report.FormatYAML:
+		output, err = encodeReportData(reportData, opts.format)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error encoding report data: %v\n", err)
+			return 1
+		}
+	case opts.format == report.FormatHTML:
+		output, err = newGenerator(cfg, "").GenerateHTML(reportData)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error generating HTML report: %v\n", err)
+			return 1
+		}
+	case opts.format == report.FormatConfluence:
+		output, err = newGenerator(cfg, "").GenerateConfluence(reportData)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error generating Confluence storage format: %v\n", err)
+			return 1
+		}
+	case opts.format == report.FormatText:
+		output = newGenerator(cfg, "").GenerateText(reportData, opts.maxChars)
+	case opts.format == report.FormatFeishu || opts.format == report.FormatDingTalk || opts.format == report.FormatWeCom ||
+		opts.format == report.FormatSlack || opts.format == report.FormatTeams:
+		output, err = renderCards(newGenerator(cfg, ""), reportData, opts.format, report.CardOptions{ReportURL: opts.reportURL})
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error generating %s messages: %v\n", opts.format, err)
+			return 1
+		}
+	case opts.format != report.FormatMarkdown:
+		fmt.Fprintf(os.Stderr, "Error: unsupported format %q\n", opts.format)
+		return 2
+	case cfg.Report.Mode == "llm":
+		output, err = generateWithLLM(ctx, cfg.Report.LLM, reportData, opts.noCache)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error generating report with LLM: %v\n", err)
+			return 1
+		}
+	default:
+		generator := newGenerator(cfg, cfg.Report.TemplatePath)
+		generator.SetStrict(opts.strict)
+		output, err = generator.Generate(reportData)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error generating report: %v\n", err)
+			return 1
+		}
+		for _, warning := range generator.Warnings() {
+			fmt.Fprintf(os.Stderr, "Warning: %s\n", warning)
+		}
+	}
+
+	// Output report
+	if opts.outputPath != "" {
+		if err := os.WriteFile(opts.outputPath, []byte(output), 0644); err != nil {
+			fmt.Fprintf(os.Stderr, "Error writing output file: %v\n", err)
+			return 1
+		}
+		fmt.Printf("Report generated: %s\n", opts.outputPath)
+	} else if opts.publishTo == "" {
+		fmt.Print(output)
+	}
+
+	// Publish report
+	if opts.publishTo != "" {
+		results, err := publishReport(ctx, cfg, reportData, strings.Split(opts.publishTo, ","))
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error publishing report: %v\n", err)
+			return 1
+		}
+		fmt.Print(publish.FormatSummary(results))
+		if publish.Failed(results) {
+			return 1
+		}
+	}
+
+	if !opts.noHistory {
+		saveHistory(cfg, reportData, opts.format, output)
+	}
+	return 0
+}
+
+// publishReport sends the report to the named publish targets
+func publishReport(ctx context.Context, cfg *config.Config, data *models.ReportData, names []string) ([]publish.Result, error) {
+	targets, err := publish.Select(cfg.Publish, names)
+	if err != nil {
+		return nil, err
+	}
+
+	publishers := make([]publish.Publisher, 0, len(targets))
+	for _, target := range targets {
+		p, err := publish.New(target, newGenerator(cfg, cfg.Report.TemplatePath))
+		if err != nil {
+			return nil, err
+		}
+		publishers = append(publishers, p)
+	}
+
+	return publish.Run(ctx, publishers, data), nil
+}
+
+// newRangeParser returns a date expression parser honoring the configured timezone, day start and calendar
+func newRangeParser(cfg *config.Config) (*timeutil.RangeParser, error) {
+	parser, err := timeutil.NewRangeParser(cfg.Time.Timezone)
+	if err != nil {
+		return nil, err
+	}
+	parser.DayStart, err = timeutil.ParseClock(cfg.Time.DayStart)
+	if err != nil {
+		return nil, err
+	}
+	parser.Calendar, err = calendar.Load(cfg.Calendar.Builtin, cfg.Calendar.Files...)
+	if err != nil {
+		return nil, err
+	}
+	return parser, nil
+}
+
+// periodRange widens the workday containing t to the given period
+func periodRange(cfg *config.Config, period string, t time.Time) (time.Time, time.Time, error) {
+	parser, err := newRangeParser(cfg)
+	if err != nil {
+		return time.Time{}, time.Time{}, err
+	}
+	return parser.PeriodRange(period, t)
+}
+
+// sinceLast is the --date expression covering the time since the last report
+const sinceLast = "since-last"
+
+// parseRange resolves --date, or the exact --from/--to bounds when either is given
+func parseRange(cfg *config.Config, dateRange, from, to, period string) (time.Time, time.Time, error) {
+	parser, err := newRangeParser(cfg)
+	if err != nil {
+		return time.Time{}, time.Time{}, err
+	}
+
+	dateSet := false
+	flag.Visit(func(f *flag.Flag) { dateSet = dateSet || f.Name == "date" })
+
+	if from != "" || to != "" {
+		if dateSet {
+			return time.Time{}, time.Time{}, fmt.Errorf("--date cannot be combined with --from/--to")
+		}
+		if period != models.PeriodDay {
+			return time.Time{}, time.Time{}, fmt.Errorf("--period cannot be combined with --from/--to")
+		}
+		return parser.ParseBounds(from, to)
+	}
+
+	if dateRange == sinceLast {
+		if period != models.PeriodDay {
+			return time.Time{}, time.Time{}, fmt.Errorf("--period cannot be combined with --date %s", sinceLast)
+		}
+		return sinceLastRange(cfg)
+	}
+
+	// Early in the morning the report is usually about the previous workday
+	if !dateSet && cfg.Calendar.PreviousWorkdayBefore != "" {
+		before, err := timeutil.ParseClock(cfg.Calendar.PreviousWorkdayBefore)
+		if err != nil {
+			return time.Time{}, time.Time{}, err
+		}
+		if parser.BeforeClock(before) {
+			dateRange = "prev-workday"
+		}
+	}
+	return parser.Parse(dateRange)
+}
+
+// sinceLastRange returns the range from the end of the profile's last report until now
+func sinceLastRange(cfg *config.Config) (time.Time, time.Time, error) {
+	store, err := state.Open(cfg.State.Dir)
+	if err != nil {
+		return time.Time{}, time.Time{}, err
+	}
+	last, ok, err := store.LastReportEnd(cfg.State.Profile)
+	if err != nil {
+		return time.Time{}, time.Time{}, err
+	}
+	if !ok {
+		return time.Time{}, time.Time{}, fmt.Errorf("no previous report recorded for profile %q; generate one with --date first", cfg.State.Profile)
+	}
+
+	loc, err := time.LoadLocation(cfg.Time.Timezone)
+	if err != nil {
+		return time.Time{}, time.Time{}, err
+	}
+	// The recorded end was included in the last report
+	start := last.Add(time.Nanosecond).In(loc)
+	now := time.Now().In(loc)
+	if !start.Before(now) {
+		return time.Time{}, time.Time{}, fmt.Errorf("last report already ends at %s", last.In(loc).Format("2006-01-02 15:04"))
+	}
+	return start, now, nil
+}
+
+// sinceLastRanges warns when the gap since the last report spans several workdays, or splits it into days
+func sinceLastRanges(cfg *config.Config, start, end time.Time, split bool) ([]timeutil.Range, error) {
+	parser, err := newRangeParser(cfg)
+	if err != nil {
+		return nil, err
+	}
+	if split {
+		return parser.SplitDays(start, end), nil
+	}
+
+	if workdays := parser.Workdays(start, end); len(workdays) > 1 {
+		dates := make([]string, 0, len(workdays))
+		for _, day := range workdays {
+			dates = append(dates, day.Format("2006-01-02"))
+		}
+		fmt.Fprintf(os.Stderr, "Warning: the time since the last report spans %d workdays (%s); use --split-days for one report per day\n",
+			len(workdays), strings.Join(dates, ", "))
+	}
+	return []timeutil.Range{{Start: start, End: end}}, nil
+}
+
+// splitOutputPath inserts the day before the extension of --output, e.g. report-2026-10-19.md
+func splitOutputPath(path string, day time.Time) string {
+	if path == "" {
+		return ""
+	}
+	ext := filepath.Ext(path)
+	return strings.TrimSuffix(path, ext) + "-" + day.Format("2006-01-02") + ext
+}
+
+// recordReportEnd stores where the report stopped collecting; it never records a time in the future
+func recordReportEnd(cfg *config.Config, end time.Time) {
+	if now := time.Now(); end.After(now) {
+		end = now
+	}
+
+	store, err := state.Open(cfg.State.Dir)
+	if err == nil {
+		err = store.SetLastReportEnd(cfg.State.Profile, end)
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: failed to record report end: %v\n", err)
+	}
+}
+
+// collectReportData runs all collectors over the time range and assembles the report data
+func collectReportData(ctx context.Context, cfg *config.Config, start, end time.Time) *models.ReportData {
 	// Create collectors
 	gitCollector := collector.NewGitCollector(cfg.Git)
 	multiCollector := collector.NewMultiCollector(gitCollector)
+	for name, tz := range cfg.Time.SourceTimezones {
+		if loc, err := time.LoadLocation(tz); err == nil {
+			multiCollector.SetTimezone(name, loc)
+		}
+	}
 
 	// Collect data
-	ctx := context.Background()
 	itemsByType, sourceStatus := multiCollector.CollectAll(ctx, start, end)
 
 	// Flatten items
@@ -81,6 +518,13 @@ func main() {
 		ItemsByType:  itemsByType,
 		Stats:        make(map[string]int),
 		SourceStatus: sourceStatus,
+		Meta: models.GenerationMeta{
+			SchemaVersion: models.SchemaVersion,
+			GeneratedAt:   time.Now(),
+			Generator:     "daily_report",
+			Timezone:      cfg.Time.Timezone,
+			DayStart:      cfg.Time.DayStart,
+		},
 	}
 
 	// Calculate stats
@@ -88,23 +532,97 @@ func main() {
 		reportData.Stats[typ] = len(items)
 	}
 
-	// Generate report
+	return reportData
+}
+
+// loadReportData reads report data exported with --format json or yaml
+func loadReportData(path string) (*models.ReportData, error) {
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read input file: %w", err)
+	}
+	return report.DecodeReportData(raw)
+}
+
+// encodeReportData encodes report data in a machine-readable format
+func encodeReportData(data *models.ReportData, format string) (string, error) {
+	var out []byte
+	var err error
+	if format == report.FormatYAML {
+		out, err = report.EncodeYAML(data)
+	} else {
+		out, err = report.EncodeJSON(data)
+	}
+	return string(out), err
+}
+
+// renderCards renders chat card payloads, one JSON message per line
+func renderCards(generator *report.Generator, data *models.ReportData, format string, opts report.CardOptions) (string, error) {
+	var messages [][]byte
+	var err error
+	switch format {
+	case report.FormatFeishu:
+		messages, err = generator.FeishuCards(data, opts)
+	case report.FormatDingTalk:
+		messages, err = generator.DingTalkMessages(data, opts)
+	case report.FormatWeCom:
+		messages, err = generator.WeComMessages(data, opts)
+	case report.FormatSlack:
+		messages, err = generator.SlackMessages(data, opts)
+	case report.FormatTeams:
+		messages, err = generator.TeamsCards(data, opts)
+	}
+	if err != nil {
+		return "", err
+	}
+
+	var sb strings.Builder
+	for _, msg := range messages {
+		sb.Write(msg)
+		sb.WriteString("\n")
+	}
+	return sb.String(), nil
+}
+
+// newGenerator creates a report generator for the given template reference and config
+func newGenerator(cfg *config.Config, templateRef string) *report.Generator {
 	var generator *report.Generator
-	if cfg.Report.TemplatePath != "" {
-		generator = report.NewGeneratorWithTemplatePath(cfg.Report.TemplatePath)
+	if templateRef != "" {
+		generator = report.NewGeneratorWithTemplatePath(templateRef)
+		generator.SetLoader(report.NewTemplateLoader(append(cfg.Report.TemplateDirs, report.DefaultSearchPath()...)...))
 	} else {
 		generator = report.NewGenerator()
 	}
-	markdown := generator.Generate(reportData)
+	generator.SetEngine(cfg.Report.TemplateEngine)
+	generator.SetRequiredSections(cfg.Report.RequiredSections)
+	generator.SetSections(cfg.Report.Sections)
+	generator.SetSkipEmptyDays(cfg.Report.SkipEmptyDays)
+	if loc, err := time.LoadLocation(cfg.Time.Timezone); err == nil {
+		generator.SetLocation(loc)
+	}
+	generator.SetShowSourceTimezone(cfg.Time.ShowSourceTimezone)
+	return generator
+}
 
-	// Output report
-	if *outputPath != "" {
-		if err := os.WriteFile(*outputPath, []byte(markdown), 0644); err != nil {
-			fmt.Fprintf(os.Stderr, "Error writing output file: %v\n", err)
-			os.Exit(1)
+// generateWithLLM generates the report through the configured LLM, consulting the response cache unless disabled
+func generateWithLLM(ctx context.Context, cfg config.LLMConfig, data *models.ReportData, noCache bool) (string, error) {
+	client, err := llm.NewClient(cfg)
+	if err != nil {
+		return "", err
+	}
+
+	var cache *llm.Cache
+	if !noCache {
+		cache, err = llm.NewCache(cfg.Cache.Dir, cfg.Cache.TTL)
+		if err != nil {
+			return "", err
 		}
-		fmt.Printf("Report generated: %s\n", *outputPath)
-	} else {
-		fmt.Print(markdown)
 	}
+
+	generator := llm.NewGenerator(cfg, client, cache)
+	output, err := generator.Generate(ctx, data)
+	for _, warning := range generator.Warnings() {
+		fmt.Fprintf(os.Stderr, "Warning: %s\n", warning)
+	}
+	return output, err
 }
@@ -0,0 +1,88 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"daily_report/internal/config"
+	"daily_report/internal/history"
+	"daily_report/internal/search"
+)
+
+// runSearch implements the "search" subcommand
+func runSearch(args []string) int {
+	fs := flag.NewFlagSet("search", flag.ExitOnError)
+	configPath := fs.String("config", "config.yaml", "Path to config file")
+	limit := fs.Int("limit", 20, "Maximum number of results (0: unlimited)")
+	fs.Usage = func() {
+		fmt.Fprintf(fs.Output(), "Usage: daily_report search [--config FILE] [--limit N] QUERY\n\n")
+		fmt.Fprintf(fs.Output(), "QUERY words must all match; filters: repo:NAME type:TYPE before:YYYY-MM-DD after:YYYY-MM-DD\n\n")
+		fs.PrintDefaults()
+	}
+	fs.Parse(args)
+
+	if fs.NArg() == 0 {
+		fs.Usage()
+		return 2
+	}
+	q, err := search.ParseQuery(strings.Join(fs.Args(), " "))
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return 2
+	}
+
+	cfg, err := config.Load(*configPath)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error loading config: %v\n", err)
+		return 1
+	}
+	loc, err := time.LoadLocation(cfg.Time.Timezone)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return 1
+	}
+	store, err := openHistory(cfg)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error opening history: %v\n", err)
+		return 1
+	}
+	// The index sits next to, not inside, the profile's entries so history listing ignores it
+	indexPath := filepath.Join(store.Dir(), "index-"+history.ProfileKey(cfg.State.Profile)+".json")
+	idx, err := search.Open(indexPath, store, cfg.State.Profile)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error opening search index: %v\n", err)
+		return 1
+	}
+	for _, warning := range idx.Warnings() {
+		fmt.Fprintf(os.Stderr, "Warning: %s\n", warning)
+	}
+
+	docs := idx.Search(q)
+	if len(docs) == 0 {
+		fmt.Println("No matching items")
+		return 0
+	}
+	total := len(docs)
+	if *limit > 0 && total > *limit {
+		docs = docs[:*limit]
+	}
+
+	for _, d := range docs {
+		label := d.Type
+		if d.Repo != "" {
+			label += "/" + d.Repo
+		}
+		fmt.Printf("%s %s  [%s] %s\n", d.Date, d.Time.In(loc).Format("15:04"), label, d.Title)
+		if d.Link != "" {
+			fmt.Printf("    %s\n", d.Link)
+		}
+	}
+	if len(docs) < total {
+		fmt.Printf("\n%d of %d matches shown, use --limit to see more\n", len(docs), total)
+	}
+	return 0
+}
@@ -0,0 +1,138 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"daily_report/internal/config"
+	"daily_report/internal/history"
+	"daily_report/internal/stats"
+	"daily_report/pkg/models"
+)
+
+// runStats implements the "stats" subcommand
+func runStats(args []string) int {
+	fs := flag.NewFlagSet("stats", flag.ExitOnError)
+	configPath := fs.String("config", "config.yaml", "Path to config file")
+	dateRange := fs.String("date", "-27d,", "Range to analyze, same expressions as the report --date (default: the last 4 weeks)")
+	by := fs.String("by", models.PeriodDay, "Bucket size: day, week or month")
+	collect := fs.Bool("collect", false, "Collect the range from the sources instead of reading report history")
+	csvPath := fs.String("csv", "", "Also write one CSV row per bucket to this file (-: stdout instead of the tables)")
+	repoCSVPath := fs.String("repo-csv", "", "Also write one CSV row per bucket and repository to this file (-: stdout instead of the tables)")
+	fs.Usage = func() {
+		fmt.Fprintf(fs.Output(), "Usage: daily_report stats [--config FILE] [--date RANGE] [--by day|week|month] [--collect] [--csv FILE] [--repo-csv FILE]\n\n")
+		fs.PrintDefaults()
+	}
+	fs.Parse(args)
+
+	if fs.NArg() > 0 {
+		fs.Usage()
+		return 2
+	}
+	// Both tables on stdout would run together into one unparseable CSV
+	if *csvPath == "-" && *repoCSVPath == "-" {
+		fmt.Fprintf(os.Stderr, "Error: only one of --csv and --repo-csv can write to stdout\n")
+		return 2
+	}
+
+	cfg, err := config.Load(*configPath)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error loading config: %v\n", err)
+		return 1
+	}
+	parser, err := newRangeParser(cfg)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return 1
+	}
+	start, end, err := parser.Parse(*dateRange)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error parsing time range: %v\n", err)
+		return 1
+	}
+
+	var items []models.Item
+	if *collect {
+		items = collectReportData(context.Background(), cfg, start, end).Items
+	} else {
+		items, err = historyItems(cfg, start, end)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error reading history: %v\n", err)
+			return 1
+		}
+		if len(items) == 0 {
+			fmt.Fprintf(os.Stderr, "Warning: no stored reports cover %s - %s; use --collect to read the sources\n",
+				start.Format("2006-01-02"), end.Format("2006-01-02"))
+		}
+	}
+
+	series, err := stats.Compute(items, start, end, parser.DayStart, *by)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return 2
+	}
+
+	if err := writeStatsCSV(*csvPath, series.WriteCSV); err != nil {
+		fmt.Fprintf(os.Stderr, "Error writing CSV: %v\n", err)
+		return 1
+	}
+	if err := writeStatsCSV(*repoCSVPath, series.WriteRepoCSV); err != nil {
+		fmt.Fprintf(os.Stderr, "Error writing CSV: %v\n", err)
+		return 1
+	}
+	if *csvPath == "-" || *repoCSVPath == "-" {
+		return 0
+	}
+
+	fmt.Printf("%s - %s, per %s\n\n", start.Format("2006-01-02"), end.Format("2006-01-02"), *by)
+	fmt.Print(series.Format())
+	return 0
+}
+
+// historyItems returns the distinct items of the stored reports overlapping start and end
+func historyItems(cfg *config.Config, start, end time.Time) ([]models.Item, error) {
+	store, err := openHistory(cfg)
+	if err != nil {
+		return nil, err
+	}
+	entries, err := store.All(cfg.State.Profile)
+	if err != nil {
+		return nil, err
+	}
+
+	var overlapping []history.Entry
+	for _, e := range entries {
+		if e.Data != nil && !e.Data.StartTime.After(end) && !e.Data.EndTime.Before(start) {
+			overlapping = append(overlapping, e)
+		}
+	}
+	return history.Items(overlapping), nil
+}
+
+// writeStatsCSV writes CSV to path, or to stdout for "-"; an empty path writes nothing
+func writeStatsCSV(path string, write func(io.Writer) error) error {
+	switch path {
+	case "":
+		return nil
+	case "-":
+		return write(os.Stdout)
+	}
+
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	if err := write(f); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+	fmt.Printf("CSV written: %s\n", path)
+	return nil
+}
@@ -0,0 +1,60 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+
+	"daily_report/internal/config"
+)
+
+// runTemplate implements the "template" subcommand
+func runTemplate(args []string) int {
+	if len(args) == 0 || args[0] != "check" {
+		fmt.Fprintf(os.Stderr, "Usage: daily_report template check [--config FILE] [template...]\n")
+		return 2
+	}
+
+	fs := flag.NewFlagSet("template check", flag.ExitOnError)
+	configPath := fs.String("config", "config.yaml", "Path to config file")
+	fs.Parse(args[1:])
+
+	cfg, err := config.Load(*configPath)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error loading config: %v\n", err)
+		return 1
+	}
+
+	refs := fs.Args()
+	if len(refs) == 0 {
+		if cfg.Report.TemplatePath == "" {
+			fmt.Fprintf(os.Stderr, "No template given and report.template_path is not set\n")
+			return 2
+		}
+		refs = []string{cfg.Report.TemplatePath}
+	}
+
+	// Every issue counts as a failure when checking explicitly
+	failed := false
+	for _, ref := range refs {
+		issues, err := newGenerator(cfg, ref).Validate()
+		if err != nil {
+			fmt.Printf("%s: error: %v\n", ref, err)
+			failed = true
+			continue
+		}
+		for _, issue := range issues {
+			fmt.Println(issue)
+		}
+		if len(issues) > 0 {
+			failed = true
+			continue
+		}
+		fmt.Printf("%s: ok\n", ref)
+	}
+
+	if failed {
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,150 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"daily_report/internal/config"
+	"daily_report/internal/worklog"
+)
+
+// runWorklog implements the "worklog" subcommand
+func runWorklog(args []string) int {
+	fs := flag.NewFlagSet("worklog", flag.ExitOnError)
+	configPath := fs.String("config", "config.yaml", "Path to config file")
+	dateRange := fs.String("date", "today", "Day to log work for: today, yesterday, prev-workday, -2d, last-friday or YYYY-MM-DD")
+	dryRun := fs.Bool("dry-run", false, "Show proposed worklogs without posting them")
+	yes := fs.Bool("yes", false, "Post without asking for confirmation")
+	fs.Usage = func() {
+		fmt.Fprintf(fs.Output(), "Usage: daily_report worklog [--config FILE] [--date DAY] [--dry-run] [--yes]\n\n")
+		fs.PrintDefaults()
+	}
+	fs.Parse(args)
+
+	cfg, err := config.Load(*configPath)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error loading config: %v\n", err)
+		return 1
+	}
+
+	parser, err := newRangeParser(cfg)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return 1
+	}
+	start, end, err := parser.Parse(*dateRange)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error parsing time range: %v\n", err)
+		return 1
+	}
+	// Worklogs are proposed and marked per day; a longer range would merge days and log them again later
+	if days := parser.SplitDays(start, end); len(days) > 1 {
+		fmt.Fprintf(os.Stderr, "Error: --date %s spans %d days; log work one day at a time\n", *dateRange, len(days))
+		return 2
+	}
+
+	ctx := context.Background()
+	data := collectReportData(ctx, cfg, start, end)
+	entries, unassigned := worklog.Propose(data.Items, cfg.Worklog, cfg.Jira.ProjectKey)
+	if len(entries) == 0 {
+		fmt.Printf("No commits or meetings reference a Jira issue (%d item(s) without issue key)\n", unassigned)
+		return 0
+	}
+
+	// Entries already posted by an earlier run are skipped
+	marker := worklog.Marker(start, cfg.Jira.Username)
+	var client *worklog.JiraClient
+	if cfg.Jira.URL != "" {
+		client = worklog.NewJiraClient(cfg.Jira)
+		for i := range entries {
+			logged, err := client.Logged(ctx, entries[i].Issue, marker)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error checking worklogs of %s: %v\n", entries[i].Issue, err)
+				return 1
+			}
+			entries[i].Logged = logged
+		}
+	}
+
+	printWorklogEntries(os.Stdout, entries, parser.Location)
+	if unassigned > 0 {
+		fmt.Printf("%d item(s) without issue key were not assigned\n", unassigned)
+	}
+
+	pending := 0
+	for _, e := range entries {
+		if !e.Logged {
+			pending++
+		}
+	}
+	if *dryRun || pending == 0 {
+		return 0
+	}
+	if client == nil {
+		fmt.Fprintf(os.Stderr, "Error: jira.url is not configured\n")
+		return 1
+	}
+
+	if !*yes && !confirm(os.Stdin, fmt.Sprintf("Post %d worklog(s) to Jira? [y/N] ", pending)) {
+		fmt.Println("Aborted")
+		return 0
+	}
+
+	failed := false
+	for _, e := range entries {
+		if e.Logged {
+			continue
+		}
+		if err := client.Add(ctx, e, marker); err != nil {
+			fmt.Printf("❌ %s: %v\n", e.Issue, err)
+			failed = true
+			continue
+		}
+		fmt.Printf("✅ %s: logged %s\n", e.Issue, formatWorklogDuration(e.Duration))
+	}
+
+	if failed {
+		return 1
+	}
+	return 0
+}
+
+// printWorklogEntries prints the proposed worklogs as a table with start times in loc
+func printWorklogEntries(w io.Writer, entries []worklog.Entry, loc *time.Location) {
+	fmt.Fprintf(w, "%-12s %-6s %-8s %-8s %s\n", "ISSUE", "START", "TIME", "SOURCES", "STATUS")
+	for _, e := range entries {
+		status := "pending"
+		if e.Logged {
+			status = "already logged"
+		}
+		sources := fmt.Sprintf("%dc/%dm", e.Commits, e.Meetings)
+		fmt.Fprintf(w, "%-12s %-6s %-8s %-8s %s\n", e.Issue, e.Started.In(loc).Format("15:04"), formatWorklogDuration(e.Duration), sources, status)
+	}
+}
+
+// formatWorklogDuration formats a duration in Jira notation, e.g. "1h 30m"
+func formatWorklogDuration(d time.Duration) string {
+	minutes := int(d.Minutes())
+	switch {
+	case minutes >= 60 && minutes%60 != 0:
+		return fmt.Sprintf("%dh %dm", minutes/60, minutes%60)
+	case minutes >= 60:
+		return fmt.Sprintf("%dh", minutes/60)
+	default:
+		return fmt.Sprintf("%dm", minutes)
+	}
+}
+
+// confirm asks a yes/no question on the given input
+func confirm(in io.Reader, question string) bool {
+	fmt.Print(question)
+	answer, _ := bufio.NewReader(in).ReadString('\n')
+	answer = strings.ToLower(strings.TrimSpace(answer))
+	return answer == "y" || answer == "yes"
+}
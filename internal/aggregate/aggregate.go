@@ -0,0 +1,114 @@
+package aggregate
+
+import (
+	"regexp"
+	"sort"
+	"strings"
+
+	"daily_report/internal/timeutil"
+	"daily_report/pkg/models"
+)
+
+// Top themes are those shared by at least minThemeCount items, at most maxThemes of them
+const (
+	maxThemes     = 5
+	minThemeCount = 2
+)
+
+// Theme sources: a conventional commit scope or "[tag]" prefix, and Jira issue keys
+var (
+	scopePattern    = regexp.MustCompile(`^(?:\[([^\]]+)\]|[A-Za-z]+\(([^)]+)\)!?:)`)
+	issueKeyPattern = regexp.MustCompile(`\b[A-Z][A-Z0-9]+-[0-9]+\b`)
+)
+
+// Build aggregates report items per workday, per repository and by theme.
+// Days cover the whole report range in the location of data.StartTime and
+// begin at the day start recorded in data.Meta.
+func Build(data *models.ReportData) *models.Aggregate {
+	agg := &models.Aggregate{}
+	loc := data.StartTime.Location()
+	dayStart, _ := timeutil.ParseClock(data.Meta.DayStart)
+
+	// One bucket per workday in the range, including empty days
+	dayIndex := make(map[string]int)
+	last := timeutil.WorkdayDate(data.EndTime.In(loc), dayStart)
+	for day := timeutil.WorkdayDate(data.StartTime.In(loc), dayStart); !day.After(last); day = day.AddDate(0, 0, 1) {
+		dayIndex[day.Format("2006-01-02")] = len(agg.Days)
+		agg.Days = append(agg.Days, models.DayStats{Date: day, Stats: make(map[string]int)})
+	}
+
+	repos := make(map[string]*models.RepoStats)
+	repoDays := make(map[string]map[string]bool)
+	themes := make(map[string]int)
+
+	for _, item := range data.Items {
+		key := timeutil.WorkdayDate(item.Time.In(loc), dayStart).Format("2006-01-02")
+		if i, ok := dayIndex[key]; ok {
+			agg.Days[i].Stats[item.Type]++
+			agg.Days[i].Total++
+		}
+
+		if repo, ok := item.Metadata["repo"].(string); ok && item.Type == "git" {
+			if repos[repo] == nil {
+				repos[repo] = &models.RepoStats{Repo: repo}
+				repoDays[repo] = make(map[string]bool)
+			}
+			repos[repo].Commits++
+			repoDays[repo][key] = true
+		}
+
+		for _, theme := range itemThemes(item.Title) {
+			themes[theme]++
+		}
+	}
+
+	for repo, stats := range repos {
+		stats.ActiveDays = len(repoDays[repo])
+		agg.Repos = append(agg.Repos, *stats)
+	}
+	sort.Slice(agg.Repos, func(i, j int) bool {
+		if agg.Repos[i].Commits != agg.Repos[j].Commits {
+			return agg.Repos[i].Commits > agg.Repos[j].Commits
+		}
+		return agg.Repos[i].Repo < agg.Repos[j].Repo
+	})
+
+	for theme, count := range themes {
+		if count < minThemeCount {
+			continue
+		}
+		agg.Themes = append(agg.Themes, models.ThemeStats{Theme: theme, Count: count})
+	}
+	sort.Slice(agg.Themes, func(i, j int) bool {
+		if agg.Themes[i].Count != agg.Themes[j].Count {
+			return agg.Themes[i].Count > agg.Themes[j].Count
+		}
+		return agg.Themes[i].Theme < agg.Themes[j].Theme
+	})
+	if len(agg.Themes) > maxThemes {
+		agg.Themes = agg.Themes[:maxThemes]
+	}
+
+	return agg
+}
+
+// itemThemes returns the distinct themes of a title
+func itemThemes(title string) []string {
+	var themes []string
+	seen := make(map[string]bool)
+	add := func(theme string) {
+		theme = strings.TrimSpace(theme)
+		if theme != "" && !seen[theme] {
+			seen[theme] = true
+			themes = append(themes, theme)
+		}
+	}
+
+	if m := scopePattern.FindStringSubmatch(title); m != nil {
+		add(strings.ToLower(m[1] + m[2]))
+	}
+	for _, key := range issueKeyPattern.FindAllString(title, -1) {
+		add(key)
+	}
+	return themes
+}
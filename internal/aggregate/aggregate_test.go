@@ -0,0 +1,56 @@
+package aggregate
+
+import (
+	"testing"
+	"time"
+
+	"daily_report/pkg/models"
+)
+
+func TestBuild(t *testing.T) {
+	loc := time.FixedZone("CST", 8*3600)
+	start := time.Date(2026, 10, 12, 0, 0, 0, 0, loc)
+	commit := func(day, hour int, repo, title string) models.Item {
+		return models.Item{Type: "git", Title: title, Time: time.Date(2026, 10, day, hour, 0, 0, 0, loc),
+			Metadata: map[string]interface{}{"repo": repo}}
+	}
+
+	data := &models.ReportData{
+		StartTime: start,
+		EndTime:   start.AddDate(0, 0, 7).Add(-time.Nanosecond),
+		Items: []models.Item{
+			commit(12, 10, "api", "fix(auth): login timeout PROJ-1"),
+			commit(12, 11, "api", "feat(auth): token refresh"),
+			commit(14, 9, "web", "[UI] new button"),
+			commit(14, 10, "api", "fix(export): csv PROJ-1"),
+			{Type: "meeting", Title: "周会", Time: time.Date(2026, 10, 16, 10, 0, 0, 0, loc)},
+			// 2026-10-17 01:00 in CST even though it is the 16th in UTC
+			{Type: "jira", Title: "PROJ-2 report", Time: time.Date(2026, 10, 16, 17, 0, 0, 0, time.UTC)},
+		},
+	}
+
+	agg := Build(data)
+
+	if len(agg.Days) != 7 {
+		t.Fatalf("Expected 7 days, got %d", len(agg.Days))
+	}
+	if agg.Days[0].Total != 2 || agg.Days[0].Stats["git"] != 2 {
+		t.Errorf("Unexpected Monday stats: %+v", agg.Days[0])
+	}
+	if agg.Days[1].Total != 0 {
+		t.Errorf("Expected empty Tuesday, got %+v", agg.Days[1])
+	}
+	if agg.Days[5].Stats["jira"] != 1 {
+		t.Errorf("Expected the Jira item on Saturday in the report timezone, got %+v", agg.Days[5])
+	}
+
+	if len(agg.Repos) != 2 || agg.Repos[0].Repo != "api" || agg.Repos[0].Commits != 3 || agg.Repos[0].ActiveDays != 2 {
+		t.Errorf("Unexpected repo stats: %+v", agg.Repos)
+	}
+
+	// Single-item themes are dropped and ties are ordered by name
+	if len(agg.Themes) != 2 || agg.Themes[0] != (models.ThemeStats{Theme: "PROJ-1", Count: 2}) ||
+		agg.Themes[1] != (models.ThemeStats{Theme: "auth", Count: 2}) {
+		t.Errorf("Expected PROJ-1 and auth as top themes, got %+v", agg.Themes)
+	}
+}
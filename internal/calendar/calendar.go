@@ -0,0 +1,137 @@
+package calendar
+
+import (
+	"embed"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+//go:embed holidays
+var builtinFS embed.FS
+
+// Builtin holiday sets
+const (
+	BuiltinCN   = "cn"
+	BuiltinNone = "none"
+)
+
+// dateLayout is the layout of dates in holiday files
+const dateLayout = "2006-01-02"
+
+// File is the YAML format of a holiday file
+type File struct {
+	Holidays []string `yaml:"holidays"` // Non-working dates or ranges, e.g. 2026-10-01..2026-10-07
+	Workdays []string `yaml:"workdays"` // Makeup working days on weekends (调休)
+}
+
+// Calendar decides which dates are workdays: Monday to Friday, minus holidays, plus makeup workdays
+type Calendar struct {
+	holidays map[string]bool
+	workdays map[string]bool
+}
+
+// New returns a calendar with weekends as the only non-working days
+func New() *Calendar {
+	return &Calendar{holidays: make(map[string]bool), workdays: make(map[string]bool)}
+}
+
+// Load returns a calendar from the named builtin set and extra holiday files; later files take precedence
+func Load(builtin string, files ...string) (*Calendar, error) {
+	c := New()
+
+	switch builtin {
+	case BuiltinNone:
+	case "", BuiltinCN:
+		data, err := builtinFS.ReadFile("holidays/" + BuiltinCN + ".yaml")
+		if err != nil {
+			return nil, fmt.Errorf("failed to read builtin holidays: %w", err)
+		}
+		if err := c.add(data, "builtin "+BuiltinCN); err != nil {
+			return nil, err
+		}
+	default:
+		return nil, fmt.Errorf("unknown builtin holidays %q: must be %s or %s", builtin, BuiltinCN, BuiltinNone)
+	}
+
+	for _, path := range files {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read holiday file: %w", err)
+		}
+		if err := c.add(data, path); err != nil {
+			return nil, err
+		}
+	}
+
+	return c, nil
+}
+
+// add merges a holiday file into the calendar
+func (c *Calendar) add(data []byte, name string) error {
+	var f File
+	if err := yaml.Unmarshal(data, &f); err != nil {
+		return fmt.Errorf("failed to parse holiday file %s: %w", name, err)
+	}
+
+	for _, entry := range f.Holidays {
+		dates, err := expand(entry)
+		if err != nil {
+			return fmt.Errorf("invalid holiday in %s: %w", name, err)
+		}
+		for _, d := range dates {
+			c.holidays[d] = true
+			delete(c.workdays, d)
+		}
+	}
+	for _, entry := range f.Workdays {
+		dates, err := expand(entry)
+		if err != nil {
+			return fmt.Errorf("invalid workday in %s: %w", name, err)
+		}
+		for _, d := range dates {
+			c.workdays[d] = true
+			delete(c.holidays, d)
+		}
+	}
+
+	return nil
+}
+
+// expand turns a date or an inclusive "start..end" range into dates
+func expand(entry string) ([]string, error) {
+	from, to, isRange := strings.Cut(strings.TrimSpace(entry), "..")
+	if !isRange {
+		to = from
+	}
+
+	start, err := time.Parse(dateLayout, strings.TrimSpace(from))
+	if err != nil {
+		return nil, fmt.Errorf("%q is not a date or range", entry)
+	}
+	end, err := time.Parse(dateLayout, strings.TrimSpace(to))
+	if err != nil || end.Before(start) {
+		return nil, fmt.Errorf("%q is not a date or range", entry)
+	}
+
+	var dates []string
+	for d := start; !d.After(end); d = d.AddDate(0, 0, 1) {
+		dates = append(dates, d.Format(dateLayout))
+	}
+	return dates, nil
+}
+
+// IsWorkday reports whether t's date is a working day
+func (c *Calendar) IsWorkday(t time.Time) bool {
+	key := t.Format(dateLayout)
+	if c.workdays[key] {
+		return true
+	}
+	if c.holidays[key] {
+		return false
+	}
+	return t.Weekday() != time.Saturday && t.Weekday() != time.Sunday
+}
@@ -0,0 +1,83 @@
+package calendar
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func date(s string) time.Time {
+	t, _ := time.Parse(dateLayout, s)
+	return t
+}
+
+func TestLoad_Builtin(t *testing.T) {
+	c, err := Load(BuiltinCN)
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+
+	tests := map[string]bool{
+		"2026-10-16": true,  // Friday
+		"2026-10-17": false, // Saturday
+		"2026-10-01": false, // 国庆节 on a Thursday
+		"2026-10-10": true,  // 调休 Saturday
+		"2026-02-14": true,  // 春节调休 Saturday
+		"2026-02-16": false, // 春节 Monday
+	}
+	for d, want := range tests {
+		if got := c.IsWorkday(date(d)); got != want {
+			t.Errorf("IsWorkday(%s) = %v, want %v", d, got, want)
+		}
+	}
+}
+
+func TestLoad_UserFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "company.yaml")
+	content := `
+holidays:
+  - 2026-10-19..2026-10-20  # company retreat
+workdays:
+  - 2026-10-01  # on call
+`
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	c, err := Load(BuiltinCN, path)
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	if c.IsWorkday(date("2026-10-19")) || c.IsWorkday(date("2026-10-20")) {
+		t.Error("Expected user holiday range to be non-working")
+	}
+	if !c.IsWorkday(date("2026-10-01")) {
+		t.Error("Expected user workday to override builtin holiday")
+	}
+}
+
+func TestLoad_None(t *testing.T) {
+	c, err := Load(BuiltinNone)
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	if !c.IsWorkday(date("2026-10-01")) {
+		t.Error("Expected weekdays to be workdays without holidays")
+	}
+}
+
+func TestLoad_Errors(t *testing.T) {
+	if _, err := Load("xx"); err == nil {
+		t.Error("Expected error for unknown builtin")
+	}
+
+	path := filepath.Join(t.TempDir(), "bad.yaml")
+	os.WriteFile(path, []byte("holidays:\n  - 2026-10-07..2026-10-01\n"), 0644)
+	if _, err := Load(BuiltinNone, path); err == nil {
+		t.Error("Expected error for reversed range")
+	}
+	if _, err := Load(BuiltinNone, filepath.Join(t.TempDir(), "missing.yaml")); err == nil {
+		t.Error("Expected error for missing file")
+	}
+}
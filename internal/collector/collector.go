@@ -19,6 +19,7 @@ type Collector interface {
 // MultiCollector combines multiple collectors
 type MultiCollector struct {
 	collectors []Collector
+	timezones  map[string]*time.Location
 }
 
 // NewMultiCollector creates a new MultiCollector
@@ -28,6 +29,15 @@ func NewMultiCollector(collectors ...Collector) *MultiCollector {
 	}
 }
 
+// SetTimezone makes the named collector's timestamps read as wall-clock times in loc,
+// for sources that return times without an offset
+func (mc *MultiCollector) SetTimezone(name string, loc *time.Location) {
+	if mc.timezones == nil {
+		mc.timezones = make(map[string]*time.Location)
+	}
+	mc.timezones[name] = loc
+}
+
 // CollectAll collects items from all collectors
 func (mc *MultiCollector) CollectAll(ctx context.Context, start, end time.Time) (map[string][]models.Item, map[string]models.SourceStatus) {
 	result := make(map[string][]models.Item)
@@ -43,6 +53,11 @@ func (mc *MultiCollector) CollectAll(ctx context.Context, start, end time.Time)
 			}
 			continue
 		}
+		if loc, ok := mc.timezones[c.Name()]; ok {
+			for i := range items {
+				items[i].Time = inLocation(items[i].Time, loc)
+			}
+		}
 		result[c.Name()] = items
 		status[c.Name()] = models.SourceStatus{
 			Name:    c.Name(),
@@ -52,3 +67,8 @@ func (mc *MultiCollector) CollectAll(ctx context.Context, start, end time.Time)
 
 	return result, status
 }
+
+// inLocation keeps the wall-clock reading of t but places it in loc
+func inLocation(t time.Time, loc *time.Location) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), t.Minute(), t.Second(), t.Nanosecond(), loc)
+}
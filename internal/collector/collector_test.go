@@ -0,0 +1,41 @@
+package collector
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"daily_report/pkg/models"
+)
+
+// staticCollector returns fixed items
+type staticCollector struct {
+	name  string
+	items []models.Item
+}
+
+func (c staticCollector) Name() string { return c.name }
+
+func (c staticCollector) Collect(ctx context.Context, start, end time.Time) ([]models.Item, error) {
+	return c.items, nil
+}
+
+func TestMultiCollector_SetTimezone(t *testing.T) {
+	// A naive "09:00" parsed without an offset ends up as UTC
+	naive := time.Date(2026, 10, 19, 9, 0, 0, 0, time.UTC)
+	mc := NewMultiCollector(
+		staticCollector{name: "jira", items: []models.Item{{Type: "jira", Time: naive}}},
+		staticCollector{name: "git", items: []models.Item{{Type: "git", Time: naive}}},
+	)
+	loc := time.FixedZone("CST", 8*3600)
+	mc.SetTimezone("jira", loc)
+
+	items, _ := mc.CollectAll(context.Background(), naive, naive)
+
+	if got := items["jira"][0].Time; !got.Equal(time.Date(2026, 10, 19, 9, 0, 0, 0, loc)) {
+		t.Errorf("Expected naive time to be read in the override timezone, got %v", got)
+	}
+	if got := items["git"][0].Time; !got.Equal(naive) {
+		t.Errorf("Collectors without an override should be unchanged, got %v", got)
+	}
+}
@@ -6,6 +6,7 @@ import (
 	"os"
 	"os/exec"
 	"path/filepath"
+	"strconv"
 	"strings"
 	"time"
 
@@ -118,15 +119,16 @@ func (g *GitCollector) collectFromRepo(ctx context.Context, repo string, start,
 		return nil, fmt.Errorf("not a git repository: %s", repo)
 	}
 
-	// Get commits with author filter
-	startStr := start.Format("2006-01-02 15:04:05")
-	endStr := end.Format("2006-01-02 15:04:05")
+	// Get commits with author filter; bounds carry their offset so git does not read them in local time
+	startStr := start.Format(time.RFC3339)
+	endStr := end.Format(time.RFC3339)
 
 	cmd = exec.CommandContext(ctx, "git", "-C", repo, "log",
 		"--author="+g.cfg.Author,
 		"--since="+startStr,
 		"--until="+endStr,
-		"--pretty=format:%H|%an|%ae|%ai|%s")
+		"--numstat",
+		"--pretty=format:%x1e%H|%an|%ae|%ai|%s%n%b%x1d")
 
 	output, err := cmd.Output()
 	if err != nil {
@@ -142,11 +144,15 @@ func (g *GitCollector) parseCommits(output, repo string) ([]models.Item, error)
 		return []models.Item{}, nil
 	}
 
-	lines := strings.Split(strings.TrimSpace(output), "\n")
-	items := make([]models.Item, 0, len(lines))
+	// Each commit starts with a record separator, then a header line and the body up to a
+	// group separator, followed by its numstat lines
+	records := strings.Split(strings.TrimSpace(output), "\x1e")
+	items := make([]models.Item, 0, len(records))
 
-	for _, line := range lines {
-		parts := strings.Split(line, "|")
+	for _, record := range records {
+		text, numstat, _ := strings.Cut(record, "\x1d")
+		line, body, _ := strings.Cut(strings.TrimSpace(text), "\n")
+		parts := strings.SplitN(line, "|", 5)
 		if len(parts) < 5 {
 			continue
 		}
@@ -179,9 +185,34 @@ func (g *GitCollector) parseCommits(output, repo string) ([]models.Item, error)
 				"author_email": authorEmail,
 			},
 		}
+		if body = strings.TrimSpace(body); body != "" {
+			item.Metadata["body"] = body
+		}
+		if additions, deletions, ok := parseNumstat(numstat); ok {
+			item.Metadata["additions"] = additions
+			item.Metadata["deletions"] = deletions
+		}
 
 		items = append(items, item)
 	}
 
 	return items, nil
 }
+
+// parseNumstat sums the added and deleted lines of "git log --numstat" output; binary files count as zero
+func parseNumstat(output string) (additions, deletions int, ok bool) {
+	for _, line := range strings.Split(output, "\n") {
+		fields := strings.Split(strings.TrimSpace(line), "\t")
+		if len(fields) < 3 {
+			continue
+		}
+		ok = true
+		if added, err := strconv.Atoi(fields[0]); err == nil {
+			additions += added
+		}
+		if deleted, err := strconv.Atoi(fields[1]); err == nil {
+			deletions += deleted
+		}
+	}
+	return additions, deletions, ok
+}
@@ -94,8 +94,9 @@ func TestGitCollector_Collect_Empty(t *testing.T) {
 func TestGitCollector_ParseCommits(t *testing.T) {
 	collector := &GitCollector{}
 
-	output := `abc123|John Doe|john@example.com|2026-02-11 14:30:00 +0800|feat: add new feature
-def456|Jane Smith|jane@example.com|2026-02-11 15:45:00 +0800|fix: fix bug`
+	output := "\x1eabc123|John Doe|john@example.com|2026-02-11 14:30:00 +0800|feat: add new feature\n\x1d\n" +
+		"12\t3\tmain.go\n-\t-\tlogo.png\n5\t0\tREADME.md\n\n" +
+		"\x1edef456|Jane Smith|jane@example.com|2026-02-11 15:45:00 +0800|fix: fix bug | login timeout\nRetry the session refresh.\n\nCloses #12\n\x1d"
 
 	items, err := collector.parseCommits(output, "/path/to/repo")
 
@@ -114,6 +115,26 @@ def456|Jane Smith|jane@example.com|2026-02-11 15:45:00 +0800|fix: fix bug`
 	if items[0].Type != "git" {
 		t.Errorf("Expected type 'git', got '%s'", items[0].Type)
 	}
+
+	if _, ok := items[0].Metadata["body"]; ok {
+		t.Errorf("Expected no body for a subject-only commit, got %q", items[0].Metadata["body"])
+	}
+
+	if items[0].Metadata["additions"] != 17 || items[0].Metadata["deletions"] != 3 {
+		t.Errorf("Expected 17 additions and 3 deletions, got %v and %v", items[0].Metadata["additions"], items[0].Metadata["deletions"])
+	}
+
+	if _, ok := items[1].Metadata["additions"]; ok {
+		t.Errorf("Expected no line counts for a commit without numstat")
+	}
+
+	if items[1].Title != "fix: fix bug | login timeout" {
+		t.Errorf("Expected title with a pipe to be kept whole, got '%s'", items[1].Title)
+	}
+
+	if body := items[1].Metadata["body"]; body != "Retry the session refresh.\n\nCloses #12" {
+		t.Errorf("Expected commit body, got %q", body)
+	}
 }
 
 func TestGitCollector_ParseCommits_Empty(t *testing.T) {
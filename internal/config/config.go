@@ -3,8 +3,12 @@ package config
 import (
 	"fmt"
 	"os"
+	"path/filepath"
 	"regexp"
 	"strings"
+	"time"
+
+	"daily_report/internal/timeutil"
 
 	"gopkg.in/yaml.v3"
 )
@@ -17,6 +21,11 @@ type Config struct {
 	Confluence ConfluenceConfig `yaml:"confluence"`
 	Report     ReportConfig     `yaml:"report"`
 	Time       TimeConfig       `yaml:"time"`
+	Calendar   CalendarConfig   `yaml:"calendar"`
+	State      StateConfig      `yaml:"state"`
+	History    HistoryConfig    `yaml:"history"`
+	Publish    []PublishConfig  `yaml:"publish"`
+	Worklog    WorklogConfig    `yaml:"worklog"`
 }
 
 // GitConfig contains Git collector configuration
@@ -52,22 +61,119 @@ type ConfluenceConfig struct {
 
 // ReportConfig contains report generation configuration
 type ReportConfig struct {
-	Mode         string    `yaml:"mode"`          // template, llm
-	TemplatePath string    `yaml:"template_path"` // Path to custom template file
-	LLM          LLMConfig `yaml:"llm"`
+	Mode             string          `yaml:"mode"`              // template, llm
+	TemplatePath     string          `yaml:"template_path"`     // Path to custom template file
+	TemplateEngine   string          `yaml:"template_engine"`   // auto, legacy, go
+	TemplateDirs     []string        `yaml:"template_dirs"`     // Extra directories searched for named templates
+	RequiredSections []string        `yaml:"required_sections"` // Item types a custom template must render
+	Sections         []SectionConfig `yaml:"sections"`          // Section order, visibility and wording
+	SkipEmptyDays    bool            `yaml:"skip_empty_days"`   // Omit days without items from multi-day reports
+	LLM              LLMConfig       `yaml:"llm"`
+}
+
+// SectionConfig configures how one item type is shown in the report
+type SectionConfig struct {
+	Type    string `yaml:"type"`    // Item type, i.e. the collector name
+	Heading string `yaml:"heading"` // Section heading, e.g. "💻 代码提交"
+	Label   string `yaml:"label"`   // Label in the summary, e.g. "Git 提交"
+	Unit    string `yaml:"unit"`    // Unit in the summary, e.g. "次"
+	Hidden  bool   `yaml:"hidden"`
 }
 
 // LLMConfig contains LLM configuration for report generation
 type LLMConfig struct {
-	Provider     string `yaml:"provider"` // openai, anthropic, local
-	Model        string `yaml:"model"`
-	APIKey       string `yaml:"api_key"`
-	SystemPrompt string `yaml:"system_prompt"`
+	Provider     string         `yaml:"provider"` // openai or local (OpenAI-compatible)
+	Model        string         `yaml:"model"`
+	APIKey       string         `yaml:"api_key"`
+	BaseURL      string         `yaml:"base_url"` // Optional: OpenAI-compatible endpoint
+	SystemPrompt string         `yaml:"system_prompt"`
+	Cache        LLMCacheConfig `yaml:"cache"`
+}
+
+// LLMCacheConfig contains LLM response cache configuration
+type LLMCacheConfig struct {
+	Dir string        `yaml:"dir"` // Default: <user cache dir>/daily_report/llm
+	TTL time.Duration `yaml:"ttl"` // Default: 168h
+}
+
+// PublishConfig configures one publish target selectable with --publish
+type PublishConfig struct {
+	Name       string                  `yaml:"name"`
+	Type       string                  `yaml:"type"`        // feishu, dingtalk, wecom, slack, email, confluence
+	WebhookURL string                  `yaml:"webhook_url"` // Bot or incoming webhook URL
+	Secret     string                  `yaml:"secret"`      // Optional: signing secret (feishu, dingtalk)
+	ReportURL  string                  `yaml:"report_url"`  // Optional: link shown as a button on cards
+	Email      EmailConfig             `yaml:"email"`       // Used by the email type
+	Confluence ConfluencePublishConfig `yaml:"confluence"`  // Used by the confluence type
+	Retries    int                     `yaml:"retries"`     // Default: 2
+	Timeout    time.Duration           `yaml:"timeout"`     // Default: 10s
+}
+
+// EmailConfig contains SMTP settings for the email publish type
+type EmailConfig struct {
+	Host     string   `yaml:"host"`
+	Port     int      `yaml:"port"`     // Default: 587 for starttls, 465 for tls, 25 for none
+	Security string   `yaml:"security"` // starttls (default), tls, none
+	Username string   `yaml:"username"` // Optional: enables SMTP AUTH PLAIN
+	Password string   `yaml:"password"`
+	From     string   `yaml:"from"`
+	To       []string `yaml:"to"`
+	Cc       []string `yaml:"cc"`
+	Subject  string   `yaml:"subject"` // Go template over the report data, default: 日报 {{.Date.Format "2006-01-02"}}
+}
+
+// ConfluencePublishConfig contains page settings for the confluence publish type.
+// Connection settings default to the top-level confluence section.
+type ConfluencePublishConfig struct {
+	URL          string `yaml:"url"`
+	Username     string `yaml:"username"`
+	APIToken     string `yaml:"api_token"`
+	SpaceKey     string `yaml:"space_key"`
+	ParentID     string `yaml:"parent_id"`     // Page ID new pages are created under
+	Author       string `yaml:"author"`        // Name available as {{.Author}} in titles
+	Title        string `yaml:"title"`         // Go template, default: 日报 {{.Date.Format "2006-01-02"}} {{.Author}}
+	Monthly      bool   `yaml:"monthly"`       // Append each day to a monthly page instead
+	MonthlyTitle string `yaml:"monthly_title"` // Go template, default: 日报 {{.Date.Format "2006-01"}} {{.Author}}
+}
+
+// WorklogConfig controls how Jira worklogs are estimated from collected activity
+type WorklogConfig struct {
+	MeetingIssue string        `yaml:"meeting_issue"` // Optional: issue for meetings without an issue key
+	FirstCommit  time.Duration `yaml:"first_commit"`  // Time credited to a commit after a long gap, default: 30m
+	MaxGap       time.Duration `yaml:"max_gap"`       // Longest gap between commits counted as work, default: 2h
+	Round        time.Duration `yaml:"round"`         // Entries are rounded to this unit, default: 15m
 }
 
 // TimeConfig contains time configuration
 type TimeConfig struct {
 	Timezone string `yaml:"timezone"`
+	DayStart string `yaml:"day_start"` // Workday boundary, e.g. "04:00" counts 00:00-03:59 as the previous day
+
+	// SourceTimezones maps collector names to the timezone of their naive timestamps
+	SourceTimezones map[string]string `yaml:"source_timezones"`
+	// ShowSourceTimezone appends the original time to items recorded in another timezone
+	ShowSourceTimezone bool `yaml:"show_source_timezone"`
+}
+
+// CalendarConfig contains workday calendar configuration
+type CalendarConfig struct {
+	Builtin               string   `yaml:"builtin"`                 // Bundled holidays: cn (default) or none
+	Files                 []string `yaml:"files"`                   // Extra holiday files, later files take precedence
+	PreviousWorkdayBefore string   `yaml:"previous_workday_before"` // Default --date is the previous workday when run before this time, e.g. "10:00"
+}
+
+// StateConfig contains the location of state kept between runs
+type StateConfig struct {
+	Dir     string `yaml:"dir"`     // Default: <user config dir>/daily_report
+	Profile string `yaml:"profile"` // Key of this config's state, default: absolute config path
+}
+
+// HistoryConfig contains report history configuration
+type HistoryConfig struct {
+	Disabled   bool          `yaml:"disabled"`
+	Dir        string        `yaml:"dir"`         // Default: <state dir>/history
+	MaxAge     time.Duration `yaml:"max_age"`     // Remove reports generated longer ago, 0 keeps them
+	MaxEntries int           `yaml:"max_entries"` // Keep at most this many reports per profile, 0 for no limit
 }
 
 // Load loads configuration from a YAML file and expands environment variables
@@ -89,6 +195,82 @@ func Load(path string) (*Config, error) {
 	if cfg.Time.Timezone == "" {
 		cfg.Time.Timezone = "Asia/Shanghai"
 	}
+	if _, err := timeutil.ParseClock(cfg.Time.DayStart); err != nil {
+		return nil, fmt.Errorf("invalid time.day_start: %w", err)
+	}
+	if _, err := time.LoadLocation(cfg.Time.Timezone); err != nil {
+		return nil, fmt.Errorf("invalid time.timezone: %w", err)
+	}
+	for name, tz := range cfg.Time.SourceTimezones {
+		if _, err := time.LoadLocation(tz); err != nil {
+			return nil, fmt.Errorf("invalid time.source_timezones.%s: %w", name, err)
+		}
+	}
+	if cfg.State.Profile == "" {
+		cfg.State.Profile, err = filepath.Abs(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to resolve config path: %w", err)
+		}
+	}
+	if cfg.Calendar.Builtin == "" {
+		cfg.Calendar.Builtin = "cn"
+	}
+	if _, err := timeutil.ParseClock(cfg.Calendar.PreviousWorkdayBefore); err != nil {
+		return nil, fmt.Errorf("invalid calendar.previous_workday_before: %w", err)
+	}
+	switch cfg.Report.TemplateEngine {
+	case "":
+		cfg.Report.TemplateEngine = "auto"
+	case "auto", "legacy", "go":
+	default:
+		return nil, fmt.Errorf("invalid report.template_engine %q: must be auto, legacy or go", cfg.Report.TemplateEngine)
+	}
+	for i, section := range cfg.Report.Sections {
+		if strings.TrimSpace(section.Type) == "" {
+			return nil, fmt.Errorf("missing required config key report.sections[%d].type", i)
+		}
+	}
+	if cfg.Worklog.FirstCommit == 0 {
+		cfg.Worklog.FirstCommit = 30 * time.Minute
+	}
+	if cfg.Worklog.MaxGap == 0 {
+		cfg.Worklog.MaxGap = 2 * time.Hour
+	}
+	if cfg.Worklog.Round == 0 {
+		cfg.Worklog.Round = 15 * time.Minute
+	}
+	names := make(map[string]bool)
+	for i := range cfg.Publish {
+		target := &cfg.Publish[i]
+		if strings.TrimSpace(target.Name) == "" {
+			return nil, fmt.Errorf("missing required config key publish[%d].name", i)
+		}
+		if names[target.Name] {
+			return nil, fmt.Errorf("duplicate publish target name %q", target.Name)
+		}
+		names[target.Name] = true
+		if target.Retries == 0 {
+			target.Retries = 2
+		}
+		if target.Timeout == 0 {
+			target.Timeout = 10 * time.Second
+		}
+		if target.Type == "confluence" {
+			page := &target.Confluence
+			if page.URL == "" {
+				page.URL = cfg.Confluence.URL
+			}
+			if page.Username == "" {
+				page.Username = cfg.Confluence.Username
+			}
+			if page.APIToken == "" {
+				page.APIToken = cfg.Confluence.APIToken
+			}
+			if page.SpaceKey == "" {
+				page.SpaceKey = cfg.Confluence.SpaceKey
+			}
+		}
+	}
 	if strings.TrimSpace(cfg.Git.Author) == "" {
 		return nil, fmt.Errorf("missing required config key git.author")
 	}
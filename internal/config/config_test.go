@@ -4,6 +4,7 @@ import (
 	"os"
 	"path/filepath"
 	"testing"
+	"time"
 )
 
 func TestExpandEnvVars(t *testing.T) {
@@ -207,3 +208,113 @@ report:
 		t.Fatal("Expected error for missing git.author, got nil")
 	}
 }
+
+func TestLoad_Publish(t *testing.T) {
+	tempDir := t.TempDir()
+	configPath := filepath.Join(tempDir, "config.yaml")
+
+	yamlContent := `
+git:
+  author: "test@example.com"
+
+confluence:
+  url: "https://wiki.example.com"
+  username: "me"
+  api_token: "token"
+  space_key: "TEAM"
+
+publish:
+  - name: team
+    type: feishu
+    webhook_url: "https://open.feishu.cn/open-apis/bot/v2/hook/x"
+  - name: slack
+    type: slack
+    retries: 5
+    timeout: 3s
+  - name: wiki
+    type: confluence
+    confluence:
+      space_key: "DAILY"
+      parent_id: "42"
+`
+
+	os.WriteFile(configPath, []byte(yamlContent), 0644)
+
+	cfg, err := Load(configPath)
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+
+	if len(cfg.Publish) != 3 {
+		t.Fatalf("Expected 3 publish targets, got %d", len(cfg.Publish))
+	}
+	if cfg.Publish[0].Retries != 2 || cfg.Publish[0].Timeout != 10*time.Second {
+		t.Errorf("Expected default retries and timeout, got %d and %v", cfg.Publish[0].Retries, cfg.Publish[0].Timeout)
+	}
+	if cfg.Publish[1].Retries != 5 || cfg.Publish[1].Timeout != 3*time.Second {
+		t.Errorf("Expected configured retries and timeout, got %d and %v", cfg.Publish[1].Retries, cfg.Publish[1].Timeout)
+	}
+
+	page := cfg.Publish[2].Confluence
+	if page.URL != "https://wiki.example.com" || page.Username != "me" || page.APIToken != "token" {
+		t.Errorf("Expected confluence credentials to default from the confluence section, got %+v", page)
+	}
+	if page.SpaceKey != "DAILY" {
+		t.Errorf("Expected space key override to be kept, got %q", page.SpaceKey)
+	}
+}
+
+func TestLoad_PublishDuplicateName(t *testing.T) {
+	tempDir := t.TempDir()
+	configPath := filepath.Join(tempDir, "config.yaml")
+
+	yamlContent := `
+git:
+  author: "test@example.com"
+
+publish:
+  - name: team
+    type: feishu
+  - name: team
+    type: slack
+`
+
+	os.WriteFile(configPath, []byte(yamlContent), 0644)
+
+	if _, err := Load(configPath); err == nil {
+		t.Fatal("Expected error for duplicate publish target name, got nil")
+	}
+}
+
+func TestLoad_InvalidDayStart(t *testing.T) {
+	tempDir := t.TempDir()
+	configPath := filepath.Join(tempDir, "config.yaml")
+
+	yamlContent := `
+git:
+  author: "test@example.com"
+
+time:
+  day_start: "4am"
+`
+
+	os.WriteFile(configPath, []byte(yamlContent), 0644)
+
+	if _, err := Load(configPath); err == nil {
+		t.Fatal("Expected error for invalid time.day_start, got nil")
+	}
+}
+
+func TestLoad_StateProfileDefault(t *testing.T) {
+	tempDir := t.TempDir()
+	configPath := filepath.Join(tempDir, "config.yaml")
+	os.WriteFile(configPath, []byte("git:\n  author: \"test@example.com\"\n"), 0644)
+
+	cfg, err := Load(configPath)
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	if cfg.State.Profile != configPath {
+		t.Errorf("Expected profile to default to %s, got %s", configPath, cfg.State.Profile)
+	}
+}
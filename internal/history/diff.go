@@ -0,0 +1,96 @@
+package history
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"daily_report/pkg/models"
+)
+
+// StatChange is the item count of one type in both reports
+type StatChange struct {
+	Type string
+	Old  int
+	New  int
+}
+
+// Diff is the difference between two reports
+type Diff struct {
+	Stats   []StatChange
+	Removed []models.Item // Only in the old report
+	Added   []models.Item // Only in the new report
+	Common  int           // Items in both reports
+}
+
+// itemKey identifies an item across reports; a Jira issue or page worked on two days has the same key
+func itemKey(item models.Item) string {
+	return item.Type + "\x00" + item.Title + "\x00" + item.Link
+}
+
+// Compare returns what changed from older to newer
+func Compare(older, newer *models.ReportData) Diff {
+	var d Diff
+
+	counts := make(map[string]*StatChange)
+	stat := func(typ string) *StatChange {
+		if counts[typ] == nil {
+			counts[typ] = &StatChange{Type: typ}
+		}
+		return counts[typ]
+	}
+
+	oldKeys := make(map[string]bool)
+	for _, item := range older.Items {
+		stat(item.Type).Old++
+		oldKeys[itemKey(item)] = true
+	}
+	newKeys := make(map[string]bool)
+	for _, item := range newer.Items {
+		stat(item.Type).New++
+		newKeys[itemKey(item)] = true
+		if oldKeys[itemKey(item)] {
+			d.Common++
+		} else {
+			d.Added = append(d.Added, item)
+		}
+	}
+	for _, item := range older.Items {
+		if !newKeys[itemKey(item)] {
+			d.Removed = append(d.Removed, item)
+		}
+	}
+
+	for _, s := range counts {
+		d.Stats = append(d.Stats, *s)
+	}
+	sort.Slice(d.Stats, func(i, j int) bool { return d.Stats[i].Type < d.Stats[j].Type })
+	return d
+}
+
+// Format renders the diff as text, labelling the reports by their dates
+func (d Diff) Format(oldLabel, newLabel string) string {
+	var sb strings.Builder
+
+	fmt.Fprintf(&sb, "%-12s %10s %10s %6s\n", "TYPE", oldLabel, newLabel, "CHANGE")
+	for _, s := range d.Stats {
+		fmt.Fprintf(&sb, "%-12s %10d %10d %+6d\n", s.Type, s.Old, s.New, s.New-s.Old)
+	}
+
+	writeItems := func(title, sign string, items []models.Item) {
+		if len(items) == 0 {
+			return
+		}
+		fmt.Fprintf(&sb, "\n%s:\n", title)
+		for _, item := range items {
+			fmt.Fprintf(&sb, "%s [%s] %s\n", sign, item.Type, item.Title)
+		}
+	}
+	writeItems("Only in "+oldLabel, "-", d.Removed)
+	writeItems("Only in "+newLabel, "+", d.Added)
+
+	if d.Common > 0 {
+		fmt.Fprintf(&sb, "\n%d items in both reports\n", d.Common)
+	}
+	return sb.String()
+}
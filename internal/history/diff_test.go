@@ -0,0 +1,35 @@
+package history
+
+import (
+	"strings"
+	"testing"
+
+	"daily_report/pkg/models"
+)
+
+func TestCompare(t *testing.T) {
+	older := &models.ReportData{Items: []models.Item{
+		{Type: "git", Title: "feat: login"},
+		{Type: "jira", Title: "PROJ-1 Login page"},
+	}}
+	newer := &models.ReportData{Items: []models.Item{
+		{Type: "git", Title: "fix: logout"},
+		{Type: "git", Title: "test: logout"},
+		{Type: "jira", Title: "PROJ-1 Login page"},
+	}}
+
+	d := Compare(older, newer)
+	if d.Common != 1 || len(d.Added) != 2 || len(d.Removed) != 1 {
+		t.Fatalf("Unexpected diff: %+v", d)
+	}
+	if len(d.Stats) != 2 || d.Stats[0] != (StatChange{Type: "git", Old: 1, New: 2}) {
+		t.Errorf("Unexpected stats: %+v", d.Stats)
+	}
+
+	out := d.Format("2026-10-15", "2026-10-16")
+	for _, want := range []string{"git                   1          2     +1", "- [git] feat: login", "+ [git] fix: logout", "1 items in both reports"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("Expected output to contain %q, got:\n%s", want, out)
+		}
+	}
+}
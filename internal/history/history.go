@@ -0,0 +1,296 @@
+package history
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"daily_report/pkg/models"
+)
+
+// dateLayout is the layout of entry dates and file names
+const dateLayout = "2006-01-02"
+
+// defaultFormat is the format of entries looked up without one
+const defaultFormat = "markdown"
+
+// ErrNotFound is returned when no report is stored for a date
+var ErrNotFound = errors.New("no report in history")
+
+// Entry is one generated report
+type Entry struct {
+	Profile     string             `json:"profile"`
+	Date        string             `json:"date"`            // Report date in the report timezone, YYYY-MM-DD
+	Period      string             `json:"period"`          // day, week or month
+	Range       string             `json:"range,omitempty"` // Exact bounds of a partial range, see RangeKey; empty for a whole period
+	Format      string             `json:"format"`
+	GeneratedAt time.Time          `json:"generated_at"`
+	Data        *models.ReportData `json:"data"`
+	Output      string             `json:"output"`
+}
+
+// Store keeps generated reports on disk, one JSON file per profile, date, period, range and format
+type Store struct {
+	dir        string
+	maxAge     time.Duration
+	maxEntries int
+	now        func() time.Time
+}
+
+// Open returns a store rooted at dir; zero maxAge and maxEntries keep reports forever
+func Open(dir string, maxAge time.Duration, maxEntries int) *Store {
+	return &Store{dir: dir, maxAge: maxAge, maxEntries: maxEntries, now: time.Now}
+}
+
+// Dir returns the history directory
+func (s *Store) Dir() string {
+	return s.dir
+}
+
+// Save stores an entry, replacing an earlier report of the same date, period, range and format,
+// then applies retention
+func (s *Store) Save(e Entry) error {
+	if e.Period == "" {
+		e.Period = models.PeriodDay
+	}
+	if e.Format == "" {
+		e.Format = defaultFormat
+	}
+	if e.GeneratedAt.IsZero() {
+		e.GeneratedAt = s.now()
+	}
+
+	dir := s.profileDir(e.Profile)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return fmt.Errorf("failed to create history directory: %w", err)
+	}
+
+	data, err := json.MarshalIndent(e, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode history entry: %w", err)
+	}
+
+	// Write to a unique temp file first so readers never see a partial entry
+	// and concurrent runs do not write the same temp file
+	name := entryFile(e)
+	tmp, err := os.CreateTemp(dir, name+".*.tmp")
+	if err != nil {
+		return fmt.Errorf("failed to write history entry: %w", err)
+	}
+	_, err = tmp.Write(data)
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err == nil {
+		err = os.Chmod(tmp.Name(), 0644)
+	}
+	if err == nil {
+		err = os.Rename(tmp.Name(), filepath.Join(dir, name))
+	}
+	if err != nil {
+		os.Remove(tmp.Name())
+		return fmt.Errorf("failed to write history entry: %w", err)
+	}
+
+	_, err = s.Prune(e.Profile)
+	return err
+}
+
+// List returns the profile's entries without data and output, newest date first
+func (s *Store) List(profile string) ([]Entry, error) {
+	entries, err := s.All(profile)
+	if err != nil {
+		return nil, err
+	}
+	for i := range entries {
+		entries[i].Data = nil
+		entries[i].Output = ""
+	}
+	return entries, nil
+}
+
+// All returns the profile's entries with their data and output, newest date first
+func (s *Store) All(profile string) ([]Entry, error) {
+	files, err := os.ReadDir(s.profileDir(profile))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read history directory: %w", err)
+	}
+
+	var entries []Entry
+	for _, f := range files {
+		if f.IsDir() || !strings.HasSuffix(f.Name(), ".json") {
+			continue
+		}
+		e, err := s.read(filepath.Join(s.profileDir(profile), f.Name()))
+		if err != nil {
+			return nil, err
+		}
+		entries = append(entries, *e)
+	}
+
+	sort.Slice(entries, func(i, j int) bool {
+		if entries[i].Date != entries[j].Date {
+			return entries[i].Date > entries[j].Date
+		}
+		if entries[i].Period != entries[j].Period {
+			return entries[i].Period < entries[j].Period
+		}
+		if entries[i].Range != entries[j].Range {
+			return entries[i].Range < entries[j].Range
+		}
+		return entries[i].Format < entries[j].Format
+	})
+	return entries, nil
+}
+
+// Files returns the modification time of each of the profile's entry files by name, without
+// reading them; callers caching derived data can tell from it whether entries changed
+func (s *Store) Files(profile string) (map[string]time.Time, error) {
+	files, err := os.ReadDir(s.profileDir(profile))
+	if errors.Is(err, os.ErrNotExist) {
+		return map[string]time.Time{}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read history directory: %w", err)
+	}
+
+	m := make(map[string]time.Time, len(files))
+	for _, f := range files {
+		if f.IsDir() || !strings.HasSuffix(f.Name(), ".json") {
+			continue
+		}
+		info, err := f.Info()
+		if err != nil {
+			return nil, fmt.Errorf("failed to read history directory: %w", err)
+		}
+		m[f.Name()] = info.ModTime()
+	}
+	return m, nil
+}
+
+// Get returns the stored report of a whole day, week or month in a format; an empty format means markdown
+func (s *Store) Get(profile, date, period, format string) (*Entry, error) {
+	if period == "" {
+		period = models.PeriodDay
+	}
+	if format == "" {
+		format = defaultFormat
+	}
+	e, err := s.read(filepath.Join(s.profileDir(profile), entryFile(Entry{Date: date, Period: period, Format: format})))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, fmt.Errorf("%w for %s", ErrNotFound, date)
+	}
+	return e, err
+}
+
+// Prune removes the profile's entries older than the maximum age and beyond the maximum count
+func (s *Store) Prune(profile string) (int, error) {
+	entries, err := s.List(profile)
+	if err != nil {
+		return 0, err
+	}
+
+	removed := 0
+	cutoff := s.now().Add(-s.maxAge)
+	for i, e := range entries {
+		expired := s.maxAge > 0 && e.GeneratedAt.Before(cutoff)
+		excess := s.maxEntries > 0 && i >= s.maxEntries
+		if !expired && !excess {
+			continue
+		}
+		if err := os.Remove(filepath.Join(s.profileDir(profile), entryFile(e))); err != nil {
+			return removed, fmt.Errorf("failed to remove history entry: %w", err)
+		}
+		removed++
+	}
+	return removed, nil
+}
+
+// read loads one entry file
+func (s *Store) read(path string) (*Entry, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("failed to read history entry: %w", err)
+	}
+
+	var e Entry
+	if err := json.Unmarshal(data, &e); err != nil {
+		return nil, fmt.Errorf("failed to parse history entry %s: %w", filepath.Base(path), err)
+	}
+	return &e, nil
+}
+
+// profileDir returns the directory of a profile
+func (s *Store) profileDir(profile string) string {
+	return filepath.Join(s.dir, ProfileKey(profile))
+}
+
+// ProfileKey returns a file name safe key of a profile; profiles are hashed because they are usually paths
+func ProfileKey(profile string) string {
+	sum := sha256.Sum256([]byte(profile))
+	return hex.EncodeToString(sum[:8])
+}
+
+// entryFile names the file of an entry from its date, plus its period, range and format unless
+// they are the defaults, e.g. 2026-10-19.json, 2026-10-12.week.json or 2026-10-19.feishu.json
+func entryFile(e Entry) string {
+	parts := []string{e.Date}
+	if e.Period != models.PeriodDay && e.Period != "" {
+		parts = append(parts, e.Period)
+	}
+	if e.Range != "" {
+		parts = append(parts, e.Range)
+	}
+	if e.Format != defaultFormat && e.Format != "" {
+		parts = append(parts, e.Format)
+	}
+	return strings.Join(parts, ".") + ".json"
+}
+
+// RangeKey names a partial range by its bounds in start's location, e.g. 20261019T0900-20261019T1300
+func RangeKey(start, end time.Time) string {
+	const layout = "20060102T1504"
+	return start.Format(layout) + "-" + end.In(start.Location()).Format(layout)
+}
+
+// DateKey returns the entry date of a report in its own timezone
+func DateKey(data *models.ReportData) string {
+	return data.Date.Format(dateLayout)
+}
+
+// ItemID identifies one occurrence of an item, such as a commit kept in both a daily and a weekly report
+func ItemID(item models.Item) string {
+	return item.Type + "\x00" + item.Title + "\x00" + item.Link + "\x00" + strconv.FormatInt(item.Time.UnixNano(), 10)
+}
+
+// Items returns the distinct items of the entries' data
+func Items(entries []Entry) []models.Item {
+	var items []models.Item
+	seen := make(map[string]bool)
+	for _, e := range entries {
+		if e.Data == nil {
+			continue
+		}
+		for _, item := range e.Data.Items {
+			if id := ItemID(item); !seen[id] {
+				seen[id] = true
+				items = append(items, item)
+			}
+		}
+	}
+	return items
+}
@@ -0,0 +1,163 @@
+package history
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"daily_report/pkg/models"
+)
+
+func entry(date, period string, generated time.Time) Entry {
+	d, _ := time.Parse(dateLayout, date)
+	return Entry{
+		Profile:     "work",
+		Date:        date,
+		Period:      period,
+		Format:      "markdown",
+		GeneratedAt: generated,
+		Data:        &models.ReportData{Date: d, Items: []models.Item{{Type: "git", Title: "feat: " + date}}},
+		Output:      "# 日报 - " + date + "\n",
+	}
+}
+
+func TestStore_SaveGetList(t *testing.T) {
+	store := Open(t.TempDir(), 0, 0)
+	now := time.Date(2026, 10, 19, 18, 0, 0, 0, time.UTC)
+
+	for _, e := range []Entry{
+		entry("2026-10-16", "", now),
+		entry("2026-10-19", models.PeriodDay, now),
+		entry("2026-10-12", models.PeriodWeek, now),
+	} {
+		if err := store.Save(e); err != nil {
+			t.Fatalf("Save failed: %v", err)
+		}
+	}
+
+	got, err := store.Get("work", "2026-10-16", "", "")
+	if err != nil {
+		t.Fatalf("Get failed: %v", err)
+	}
+	if got.Output != "# 日报 - 2026-10-16\n" || len(got.Data.Items) != 1 || got.Period != models.PeriodDay {
+		t.Errorf("Unexpected entry: %+v", got)
+	}
+
+	if _, err := store.Get("work", "2026-10-12", models.PeriodDay, ""); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Expected ErrNotFound for a daily report of a weekly date, got %v", err)
+	}
+	if _, err := store.Get("home", "2026-10-16", "", ""); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Expected profiles to be separate, got %v", err)
+	}
+
+	entries, err := store.List("work")
+	if err != nil {
+		t.Fatalf("List failed: %v", err)
+	}
+	var dates []string
+	for _, e := range entries {
+		dates = append(dates, e.Date+"/"+e.Period)
+		if e.Data != nil || e.Output != "" {
+			t.Error("List should not load report contents")
+		}
+	}
+	if len(dates) != 3 || dates[0] != "2026-10-19/day" || dates[2] != "2026-10-12/week" {
+		t.Errorf("Unexpected list order: %v", dates)
+	}
+}
+
+func TestStore_SaveLeavesNoTempFiles(t *testing.T) {
+	dir := t.TempDir()
+	store := Open(dir, 0, 0)
+	now := time.Date(2026, 10, 19, 18, 0, 0, 0, time.UTC)
+
+	for i := 0; i < 2; i++ {
+		if err := store.Save(entry("2026-10-19", models.PeriodDay, now)); err != nil {
+			t.Fatalf("Save failed: %v", err)
+		}
+	}
+
+	files, _ := filepath.Glob(filepath.Join(dir, "*", "*"))
+	if len(files) != 1 || !strings.HasSuffix(files[0], "2026-10-19.json") {
+		t.Fatalf("Expected only the entry file, got %v", files)
+	}
+	info, err := os.Stat(files[0])
+	if err != nil {
+		t.Fatalf("Stat failed: %v", err)
+	}
+	if info.Mode().Perm() != 0644 {
+		t.Errorf("Expected entry file mode 0644, got %v", info.Mode().Perm())
+	}
+}
+
+func TestStore_SeparatesRangesAndFormats(t *testing.T) {
+	store := Open(t.TempDir(), 0, 0)
+	now := time.Date(2026, 10, 19, 18, 0, 0, 0, time.UTC)
+
+	full := entry("2026-10-19", "", now)
+	morning := entry("2026-10-19", "", now)
+	morning.Range = RangeKey(time.Date(2026, 10, 19, 9, 0, 0, 0, time.UTC), time.Date(2026, 10, 19, 13, 0, 0, 0, time.UTC))
+	morning.Output = "# morning\n"
+	card := entry("2026-10-19", "", now)
+	card.Format = "feishu"
+	card.Output = "{}\n"
+	for _, e := range []Entry{full, morning, card} {
+		if err := store.Save(e); err != nil {
+			t.Fatalf("Save failed: %v", err)
+		}
+	}
+
+	entries, _ := store.List("work")
+	if len(entries) != 3 {
+		t.Fatalf("Expected partial ranges and formats to be stored separately, got %+v", entries)
+	}
+	if morning.Range != "20261019T0900-20261019T1300" {
+		t.Errorf("Unexpected range key %q", morning.Range)
+	}
+
+	got, err := store.Get("work", "2026-10-19", "", "")
+	if err != nil || got.Output != full.Output {
+		t.Errorf("Expected the whole-day markdown report, got %+v, %v", got, err)
+	}
+	got, err = store.Get("work", "2026-10-19", "", "feishu")
+	if err != nil || got.Output != "{}\n" {
+		t.Errorf("Expected the feishu report, got %+v, %v", got, err)
+	}
+}
+
+func TestStore_Retention(t *testing.T) {
+	now := time.Date(2026, 10, 19, 18, 0, 0, 0, time.UTC)
+
+	store := Open(t.TempDir(), 48*time.Hour, 0)
+	store.now = func() time.Time { return now }
+	store.Save(entry("2026-10-01", "", now.Add(-72*time.Hour)))
+	store.Save(entry("2026-10-18", "", now))
+	if entries, _ := store.List("work"); len(entries) != 1 || entries[0].Date != "2026-10-18" {
+		t.Errorf("Expected old report to be removed, got %+v", entries)
+	}
+
+	store = Open(t.TempDir(), 0, 2)
+	for _, date := range []string{"2026-10-14", "2026-10-15", "2026-10-16"} {
+		store.Save(entry(date, "", now))
+	}
+	if entries, _ := store.List("work"); len(entries) != 2 || entries[1].Date != "2026-10-15" {
+		t.Errorf("Expected only the newest two reports, got %+v", entries)
+	}
+}
+
+func TestItems(t *testing.T) {
+	commit := models.Item{Type: "git", Title: "feat: login", Time: time.Date(2026, 10, 15, 10, 0, 0, 0, time.UTC)}
+	daily := Entry{Data: &models.ReportData{Items: []models.Item{commit}}}
+	weekly := Entry{Period: models.PeriodWeek, Data: &models.ReportData{Items: []models.Item{
+		commit,
+		{Type: "git", Title: "feat: login", Time: commit.Time.Add(time.Hour)},
+	}}}
+
+	items := Items([]Entry{daily, weekly, {}})
+	if len(items) != 2 {
+		t.Errorf("Expected the commit in both reports once, got %+v", items)
+	}
+}
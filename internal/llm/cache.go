@@ -0,0 +1,188 @@
+package llm
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// DefaultCacheTTL is used when no TTL is configured
+const DefaultCacheTTL = 7 * 24 * time.Hour
+
+// Cache is a local content-addressed store of LLM responses
+type Cache struct {
+	dir string
+	ttl time.Duration
+	now func() time.Time
+}
+
+// cacheEntry is the on-disk representation of a cached response
+type cacheEntry struct {
+	Key       string    `json:"key"`
+	Provider  string    `json:"provider"`
+	Model     string    `json:"model"`
+	CreatedAt time.Time `json:"created_at"`
+	Response  string    `json:"response"`
+}
+
+// NewCache creates a cache rooted at dir; an empty dir uses the user cache directory
+func NewCache(dir string, ttl time.Duration) (*Cache, error) {
+	if dir == "" {
+		base, err := os.UserCacheDir()
+		if err != nil {
+			return nil, fmt.Errorf("failed to locate user cache directory: %w", err)
+		}
+		dir = filepath.Join(base, "daily_report", "llm")
+	}
+	if ttl <= 0 {
+		ttl = DefaultCacheTTL
+	}
+
+	return &Cache{
+		dir: dir,
+		ttl: ttl,
+		now: time.Now,
+	}, nil
+}
+
+// Dir returns the cache directory
+func (c *Cache) Dir() string {
+	return c.dir
+}
+
+// CacheKey derives the cache key from provider, model and the exact system prompt and prompt sent
+func CacheKey(provider, model, systemPrompt string, prompt []byte) string {
+	systemHash := sha256.Sum256([]byte(systemPrompt))
+
+	h := sha256.New()
+	for _, part := range []string{provider, model, hex.EncodeToString(systemHash[:])} {
+		h.Write([]byte(part))
+		h.Write([]byte{0})
+	}
+	h.Write(prompt)
+
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// Get returns the cached response for key if present and not expired
+func (c *Cache) Get(key string) (string, bool, error) {
+	entry, err := c.read(c.path(key))
+	if errors.Is(err, os.ErrNotExist) {
+		return "", false, nil
+	}
+	if err != nil {
+		return "", false, err
+	}
+
+	if c.expired(entry) {
+		return "", false, nil
+	}
+
+	return entry.Response, true, nil
+}
+
+// Put stores a response under key
+func (c *Cache) Put(key, provider, model, response string) error {
+	if err := os.MkdirAll(c.dir, 0755); err != nil {
+		return fmt.Errorf("failed to create cache directory: %w", err)
+	}
+
+	data, err := json.MarshalIndent(cacheEntry{
+		Key:       key,
+		Provider:  provider,
+		Model:     model,
+		CreatedAt: c.now(),
+		Response:  response,
+	}, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode cache entry: %w", err)
+	}
+
+	// Write to a unique temp file first so concurrent readers never see a partial entry
+	// and concurrent writers of the same key do not share a temp file
+	tmp, err := os.CreateTemp(c.dir, key+".*.tmp")
+	if err != nil {
+		return fmt.Errorf("failed to write cache entry: %w", err)
+	}
+	_, err = tmp.Write(data)
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err == nil {
+		err = os.Chmod(tmp.Name(), 0644)
+	}
+	if err == nil {
+		err = os.Rename(tmp.Name(), c.path(key))
+	}
+	if err != nil {
+		os.Remove(tmp.Name())
+		return fmt.Errorf("failed to write cache entry: %w", err)
+	}
+
+	return nil
+}
+
+// Prune removes expired entries, or every entry when all is true, and returns the number removed
+func (c *Cache) Prune(all bool) (int, error) {
+	files, err := os.ReadDir(c.dir)
+	if errors.Is(err, os.ErrNotExist) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, fmt.Errorf("failed to read cache directory: %w", err)
+	}
+
+	removed := 0
+	for _, f := range files {
+		if f.IsDir() || !strings.HasSuffix(f.Name(), ".json") {
+			continue
+		}
+
+		path := filepath.Join(c.dir, f.Name())
+		if !all {
+			entry, err := c.read(path)
+			// Unreadable entries are removed as well
+			if err == nil && !c.expired(entry) {
+				continue
+			}
+		}
+
+		if err := os.Remove(path); err != nil {
+			return removed, fmt.Errorf("failed to remove cache entry: %w", err)
+		}
+		removed++
+	}
+
+	return removed, nil
+}
+
+// path returns the file path for a cache key
+func (c *Cache) path(key string) string {
+	return filepath.Join(c.dir, key+".json")
+}
+
+// read loads a cache entry from disk
+func (c *Cache) read(path string) (*cacheEntry, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var entry cacheEntry
+	if err := json.Unmarshal(data, &entry); err != nil {
+		return nil, fmt.Errorf("failed to decode cache entry %s: %w", path, err)
+	}
+
+	return &entry, nil
+}
+
+// expired reports whether an entry is older than the TTL
+func (c *Cache) expired(entry *cacheEntry) bool {
+	return c.now().Sub(entry.CreatedAt) > c.ttl
+}
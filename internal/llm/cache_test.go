@@ -0,0 +1,90 @@
+package llm
+
+import (
+	"testing"
+	"time"
+)
+
+func TestCacheKey_Stable(t *testing.T) {
+	a := CacheKey("openai", "gpt-4o", "prompt", []byte(`{"items":[]}`))
+	b := CacheKey("openai", "gpt-4o", "prompt", []byte(`{"items":[]}`))
+
+	if a != b {
+		t.Error("Expected identical inputs to produce identical keys")
+	}
+
+	variants := []string{
+		CacheKey("local", "gpt-4o", "prompt", []byte(`{"items":[]}`)),
+		CacheKey("openai", "gpt-4o-mini", "prompt", []byte(`{"items":[]}`)),
+		CacheKey("openai", "gpt-4o", "other prompt", []byte(`{"items":[]}`)),
+		CacheKey("openai", "gpt-4o", "prompt", []byte(`{"items":[1]}`)),
+	}
+	for i, v := range variants {
+		if v == a {
+			t.Errorf("Variant %d should produce a different key", i)
+		}
+	}
+}
+
+func TestCache_PutGet(t *testing.T) {
+	cache, err := NewCache(t.TempDir(), time.Hour)
+	if err != nil {
+		t.Fatalf("NewCache failed: %v", err)
+	}
+
+	if _, ok, err := cache.Get("missing"); err != nil || ok {
+		t.Fatalf("Expected miss for unknown key, got ok=%v err=%v", ok, err)
+	}
+
+	if err := cache.Put("key", "openai", "gpt-4o", "# report"); err != nil {
+		t.Fatalf("Put failed: %v", err)
+	}
+
+	response, ok, err := cache.Get("key")
+	if err != nil || !ok {
+		t.Fatalf("Expected hit, got ok=%v err=%v", ok, err)
+	}
+	if response != "# report" {
+		t.Errorf("Expected '# report', got '%s'", response)
+	}
+}
+
+func TestCache_Expiry(t *testing.T) {
+	cache, _ := NewCache(t.TempDir(), time.Hour)
+	now := time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC)
+	cache.now = func() time.Time { return now }
+
+	cache.Put("old", "openai", "gpt-4o", "old")
+	now = now.Add(30 * time.Minute)
+	cache.Put("new", "openai", "gpt-4o", "new")
+	now = now.Add(45 * time.Minute)
+
+	if _, ok, _ := cache.Get("old"); ok {
+		t.Error("Expected expired entry to miss")
+	}
+	if _, ok, _ := cache.Get("new"); !ok {
+		t.Error("Expected fresh entry to hit")
+	}
+
+	removed, err := cache.Prune(false)
+	if err != nil {
+		t.Fatalf("Prune failed: %v", err)
+	}
+	if removed != 1 {
+		t.Errorf("Expected 1 expired entry removed, got %d", removed)
+	}
+
+	removed, _ = cache.Prune(true)
+	if removed != 1 {
+		t.Errorf("Expected 1 remaining entry removed, got %d", removed)
+	}
+}
+
+func TestCache_PruneMissingDir(t *testing.T) {
+	cache, _ := NewCache(t.TempDir()+"/missing", time.Hour)
+
+	removed, err := cache.Prune(true)
+	if err != nil || removed != 0 {
+		t.Errorf("Expected no-op prune, got removed=%d err=%v", removed, err)
+	}
+}
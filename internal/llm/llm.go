@@ -0,0 +1,147 @@
+package llm
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"time"
+
+	"daily_report/internal/config"
+	"daily_report/pkg/models"
+)
+
+// Client sends a prompt to a large language model and returns its reply
+type Client interface {
+	Complete(ctx context.Context, system, prompt string) (string, error)
+}
+
+// promptTemplate wraps the collected data for the model
+const promptTemplate = `请根据以下 JSON 格式的工作产出数据生成一份 Markdown %s。
+时间范围: %s 至 %s
+
+%s
+`
+
+// Generator generates reports through an LLM, optionally backed by a response cache
+type Generator struct {
+	cfg      config.LLMConfig
+	client   Client
+	cache    *Cache
+	warnings []string
+}
+
+// NewGenerator creates a new LLM report generator; cache may be nil to disable caching
+func NewGenerator(cfg config.LLMConfig, client Client, cache *Cache) *Generator {
+	return &Generator{
+		cfg:    cfg,
+		client: client,
+		cache:  cache,
+	}
+}
+
+// Generate generates a markdown report from the collected data
+func (g *Generator) Generate(ctx context.Context, data *models.ReportData) (string, error) {
+	normalized, err := normalize(data)
+	if err != nil {
+		return "", fmt.Errorf("failed to normalize report data: %w", err)
+	}
+
+	prompt := fmt.Sprintf(promptTemplate,
+		reportKind(data.Period),
+		data.StartTime.Format("2006-01-02 15:04"),
+		data.EndTime.Format("2006-01-02 15:04"),
+		normalized)
+
+	g.warnings = nil
+	var key string
+	if g.cache != nil {
+		// The key covers the prompt exactly as sent, including its times in the report timezone
+		key = CacheKey(g.cfg.Provider, g.cfg.Model, g.cfg.SystemPrompt, []byte(prompt))
+		// An unreadable entry is a miss; it is overwritten below
+		if response, ok, err := g.cache.Get(key); err != nil {
+			g.warnings = append(g.warnings, fmt.Sprintf("ignoring LLM cache entry: %v", err))
+		} else if ok {
+			return response, nil
+		}
+	}
+
+	response, err := g.client.Complete(ctx, g.cfg.SystemPrompt, prompt)
+	if err != nil {
+		return "", err
+	}
+
+	if g.cache != nil {
+		// The response is still usable when it cannot be cached
+		if err := g.cache.Put(key, g.cfg.Provider, g.cfg.Model, response); err != nil {
+			g.warnings = append(g.warnings, fmt.Sprintf("failed to cache LLM response: %v", err))
+		}
+	}
+
+	return response, nil
+}
+
+// Warnings returns the cache problems of the last Generate call
+func (g *Generator) Warnings() []string {
+	return g.warnings
+}
+
+// reportKind names the kind of report requested for a period
+func reportKind(period string) string {
+	switch period {
+	case models.PeriodWeek:
+		return "周报（包含每日分布、仓库统计和主要方向）"
+	case models.PeriodMonth:
+		return "月报（包含每日分布、仓库统计和主要方向）"
+	default:
+		return "日报"
+	}
+}
+
+// normalizedData is the stable subset of ReportData that determines the LLM input
+type normalizedData struct {
+	StartTime    time.Time             `json:"start_time"`
+	EndTime      time.Time             `json:"end_time"`
+	Items        []models.Item         `json:"items"`
+	SourceStatus []models.SourceStatus `json:"source_status"`
+	Period       string                `json:"period,omitempty"`
+	Aggregate    *models.Aggregate     `json:"aggregate,omitempty"`
+}
+
+// normalize renders report data as deterministic JSON so equal inputs produce equal bytes
+func normalize(data *models.ReportData) ([]byte, error) {
+	n := normalizedData{
+		StartTime: data.StartTime.UTC(),
+		EndTime:   data.EndTime.UTC(),
+		Items:     make([]models.Item, 0, len(data.Items)),
+		Period:    data.Period,
+		Aggregate: data.Aggregate,
+	}
+
+	for _, item := range data.Items {
+		item.Time = item.Time.UTC()
+		n.Items = append(n.Items, item)
+	}
+	sort.SliceStable(n.Items, func(i, j int) bool {
+		a, b := n.Items[i], n.Items[j]
+		if a.Type != b.Type {
+			return a.Type < b.Type
+		}
+		if !a.Time.Equal(b.Time) {
+			return a.Time.Before(b.Time)
+		}
+		if a.Title != b.Title {
+			return a.Title < b.Title
+		}
+		return a.Link < b.Link
+	})
+
+	for _, s := range data.SourceStatus {
+		n.SourceStatus = append(n.SourceStatus, s)
+	}
+	sort.Slice(n.SourceStatus, func(i, j int) bool {
+		return n.SourceStatus[i].Name < n.SourceStatus[j].Name
+	})
+
+	return json.MarshalIndent(n, "", "  ")
+}
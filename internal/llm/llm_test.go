@@ -0,0 +1,186 @@
+package llm
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"daily_report/internal/config"
+	"daily_report/pkg/models"
+)
+
+// fakeClient records calls and returns a fixed response
+type fakeClient struct {
+	calls    int
+	response string
+	prompt   string
+}
+
+func (f *fakeClient) Complete(ctx context.Context, system, prompt string) (string, error) {
+	f.calls++
+	f.prompt = prompt
+	return f.response, nil
+}
+
+func testReportData(order []int) *models.ReportData {
+	base := time.Date(2026, 10, 18, 9, 0, 0, 0, time.UTC)
+	all := []models.Item{
+		{Type: "git", Title: "feat: a", Time: base},
+		{Type: "git", Title: "fix: b", Time: base.Add(time.Hour)},
+	}
+
+	data := &models.ReportData{
+		StartTime:    base,
+		EndTime:      base.Add(24 * time.Hour),
+		SourceStatus: map[string]models.SourceStatus{"git": {Name: "git", Success: true}},
+	}
+	for _, i := range order {
+		data.Items = append(data.Items, all[i])
+	}
+	return data
+}
+
+func TestGenerator_UsesCache(t *testing.T) {
+	cache, _ := NewCache(t.TempDir(), time.Hour)
+	client := &fakeClient{response: "# 日报"}
+	gen := NewGenerator(config.LLMConfig{Provider: "openai", Model: "gpt-4o"}, client, cache)
+
+	for _, order := range [][]int{{0, 1}, {1, 0}} {
+		result, err := gen.Generate(context.Background(), testReportData(order))
+		if err != nil {
+			t.Fatalf("Generate failed: %v", err)
+		}
+		if result != "# 日报" {
+			t.Errorf("Expected cached response, got '%s'", result)
+		}
+	}
+
+	// Item order must not affect the key
+	if client.calls != 1 {
+		t.Errorf("Expected 1 client call, got %d", client.calls)
+	}
+
+	// The same range shown in another timezone is a different prompt
+	data := testReportData([]int{0, 1})
+	shanghai := time.FixedZone("CST", 8*3600)
+	data.StartTime, data.EndTime = data.StartTime.In(shanghai), data.EndTime.In(shanghai)
+	gen.Generate(context.Background(), data)
+	if client.calls != 2 {
+		t.Errorf("Expected a new client call after a timezone change, got %d calls", client.calls)
+	}
+	if !strings.Contains(client.prompt, "2026-10-18 17:00") {
+		t.Errorf("Expected prompt times in the report timezone, got %s", client.prompt)
+	}
+}
+
+func TestGenerator_NoCache(t *testing.T) {
+	client := &fakeClient{response: "# 日报"}
+	gen := NewGenerator(config.LLMConfig{Provider: "openai", Model: "gpt-4o"}, client, nil)
+
+	gen.Generate(context.Background(), testReportData([]int{0}))
+	gen.Generate(context.Background(), testReportData([]int{0}))
+
+	if client.calls != 2 {
+		t.Errorf("Expected 2 client calls without cache, got %d", client.calls)
+	}
+}
+
+func TestGenerator_CacheErrorsAreWarnings(t *testing.T) {
+	cfg := config.LLMConfig{Provider: "openai", Model: "gpt-4o"}
+	data := testReportData([]int{0})
+	normalized, _ := normalize(data)
+	prompt := fmt.Sprintf(promptTemplate, reportKind(data.Period),
+		data.StartTime.Format("2006-01-02 15:04"), data.EndTime.Format("2006-01-02 15:04"), normalized)
+	key := CacheKey(cfg.Provider, cfg.Model, cfg.SystemPrompt, []byte(prompt))
+
+	// A corrupt entry is a miss and gets replaced
+	cache, _ := NewCache(t.TempDir(), time.Hour)
+	os.WriteFile(cache.path(key), []byte("{"), 0644)
+	client := &fakeClient{response: "# 日报"}
+	gen := NewGenerator(cfg, client, cache)
+	result, err := gen.Generate(context.Background(), data)
+	if err != nil || result != "# 日报" || client.calls != 1 {
+		t.Fatalf("Expected a fresh response, got %q, %v after %d calls", result, err, client.calls)
+	}
+	if len(gen.Warnings()) != 1 {
+		t.Errorf("Expected a warning for the corrupt entry, got %v", gen.Warnings())
+	}
+	if cached, ok, _ := cache.Get(key); !ok || cached != "# 日报" {
+		t.Errorf("Expected corrupt entry to be overwritten, got %q", cached)
+	}
+
+	// A cache that cannot be written still returns the response
+	blocked := filepath.Join(t.TempDir(), "file")
+	os.WriteFile(blocked, nil, 0644)
+	cache, _ = NewCache(filepath.Join(blocked, "llm"), time.Hour)
+	gen = NewGenerator(cfg, client, cache)
+	result, err = gen.Generate(context.Background(), data)
+	if err != nil || result != "# 日报" {
+		t.Errorf("Expected response despite cache write failure, got %q, %v", result, err)
+	}
+	if w := gen.Warnings(); len(w) == 0 || !strings.HasPrefix(w[len(w)-1], "failed to cache LLM response") {
+		t.Errorf("Expected a warning for the failed write, got %v", w)
+	}
+}
+
+func TestGenerator_WeeklyPrompt(t *testing.T) {
+	client := &fakeClient{response: "# 周报"}
+	gen := NewGenerator(config.LLMConfig{Provider: "openai", Model: "gpt-4o"}, client, nil)
+
+	data := testReportData([]int{0, 1})
+	data.Period = models.PeriodWeek
+	data.Aggregate = &models.Aggregate{Repos: []models.RepoStats{{Repo: "api", Commits: 2, ActiveDays: 1}}}
+	if _, err := gen.Generate(context.Background(), data); err != nil {
+		t.Fatalf("Generate failed: %v", err)
+	}
+
+	if !strings.Contains(client.prompt, "周报") || !strings.Contains(client.prompt, `"active_days": 1`) {
+		t.Errorf("Expected weekly prompt with aggregate, got:\n%s", client.prompt)
+	}
+}
+
+func TestOpenAIClient_Complete(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/chat/completions" {
+			t.Errorf("Unexpected path %s", r.URL.Path)
+		}
+		if r.Header.Get("Authorization") != "Bearer secret" {
+			t.Errorf("Missing bearer token")
+		}
+
+		var req chatRequest
+		json.NewDecoder(r.Body).Decode(&req)
+		if len(req.Messages) != 2 || req.Messages[0].Role != "system" {
+			t.Errorf("Expected system and user messages, got %+v", req.Messages)
+		}
+
+		w.Write([]byte(`{"choices":[{"message":{"role":"assistant","content":"hello"}}]}`))
+	}))
+	defer server.Close()
+
+	client, err := NewClient(config.LLMConfig{Provider: "local", Model: "m", APIKey: "secret", BaseURL: server.URL})
+	if err != nil {
+		t.Fatalf("NewClient failed: %v", err)
+	}
+
+	result, err := client.Complete(context.Background(), "system", "prompt")
+	if err != nil {
+		t.Fatalf("Complete failed: %v", err)
+	}
+	if result != "hello" {
+		t.Errorf("Expected 'hello', got '%s'", result)
+	}
+}
+
+func TestNewClient_UnsupportedProvider(t *testing.T) {
+	if _, err := NewClient(config.LLMConfig{Provider: "unknown"}); err == nil {
+		t.Error("Expected error for unsupported provider")
+	}
+}
@@ -0,0 +1,104 @@
+package llm
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"daily_report/internal/config"
+)
+
+// defaultOpenAIBaseURL is used when no base_url is configured
+const defaultOpenAIBaseURL = "https://api.openai.com/v1"
+
+// OpenAIClient talks to OpenAI-compatible chat completion APIs
+type OpenAIClient struct {
+	cfg        config.LLMConfig
+	httpClient *http.Client
+}
+
+// NewClient creates a client for the configured provider
+func NewClient(cfg config.LLMConfig) (Client, error) {
+	switch cfg.Provider {
+	case "openai", "local", "":
+		return &OpenAIClient{
+			cfg:        cfg,
+			httpClient: &http.Client{Timeout: 2 * time.Minute},
+		}, nil
+	default:
+		return nil, fmt.Errorf("unsupported llm provider: %s", cfg.Provider)
+	}
+}
+
+type chatMessage struct {
+	Role    string `json:"role"`
+	Content string `json:"content"`
+}
+
+type chatRequest struct {
+	Model    string        `json:"model"`
+	Messages []chatMessage `json:"messages"`
+}
+
+type chatResponse struct {
+	Choices []struct {
+		Message chatMessage `json:"message"`
+	} `json:"choices"`
+}
+
+// Complete sends a chat completion request
+func (c *OpenAIClient) Complete(ctx context.Context, system, prompt string) (string, error) {
+	var messages []chatMessage
+	if system != "" {
+		messages = append(messages, chatMessage{Role: "system", Content: system})
+	}
+	messages = append(messages, chatMessage{Role: "user", Content: prompt})
+
+	body, err := json.Marshal(chatRequest{Model: c.cfg.Model, Messages: messages})
+	if err != nil {
+		return "", fmt.Errorf("failed to encode request: %w", err)
+	}
+
+	baseURL := c.cfg.BaseURL
+	if baseURL == "" {
+		baseURL = defaultOpenAIBaseURL
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, strings.TrimRight(baseURL, "/")+"/chat/completions", bytes.NewReader(body))
+	if err != nil {
+		return "", fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if c.cfg.APIKey != "" {
+		req.Header.Set("Authorization", "Bearer "+c.cfg.APIKey)
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("failed to call llm api: %w", err)
+	}
+	defer resp.Body.Close()
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return "", fmt.Errorf("failed to read llm response: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("llm api returned %s: %s", resp.Status, strings.TrimSpace(string(respBody)))
+	}
+
+	var chat chatResponse
+	if err := json.Unmarshal(respBody, &chat); err != nil {
+		return "", fmt.Errorf("failed to decode llm response: %w", err)
+	}
+	if len(chat.Choices) == 0 {
+		return "", fmt.Errorf("llm api returned no choices")
+	}
+
+	return chat.Choices[0].Message.Content, nil
+}
@@ -0,0 +1,251 @@
+package publish
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"html"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"text/template"
+	"time"
+
+	"daily_report/internal/config"
+	"daily_report/internal/report"
+	"daily_report/pkg/models"
+)
+
+// TypeConfluence creates or updates a Confluence page
+const TypeConfluence = "confluence"
+
+// Default page title templates
+const (
+	defaultPageTitle    = `日报 {{.Date.Format "2006-01-02"}}{{with .Author}} {{.}}{{end}}`
+	defaultMonthlyTitle = `日报 {{.Date.Format "2006-01"}}{{with .Author}} {{.}}{{end}}`
+)
+
+// ConfluencePublisher writes the report to a Confluence page through the REST API
+type ConfluencePublisher struct {
+	cfg        config.PublishConfig
+	generator  *report.Generator
+	httpClient *http.Client
+	backoff    time.Duration
+}
+
+// NewConfluencePublisher creates a new Confluence publisher
+func NewConfluencePublisher(cfg config.PublishConfig, generator *report.Generator) *ConfluencePublisher {
+	return &ConfluencePublisher{
+		cfg:        cfg,
+		generator:  generator,
+		httpClient: &http.Client{Timeout: cfg.Timeout},
+		backoff:    defaultBackoff,
+	}
+}
+
+// Name returns the configured target name
+func (c *ConfluencePublisher) Name() string {
+	return c.cfg.Name
+}
+
+// confluencePage is the subset of a Confluence content object used here
+type confluencePage struct {
+	ID    string `json:"id,omitempty"`
+	Type  string `json:"type"`
+	Title string `json:"title"`
+	Space struct {
+		Key string `json:"key"`
+	} `json:"space"`
+	Version struct {
+		Number int `json:"number"`
+	} `json:"version"`
+	Ancestors []struct {
+		ID string `json:"id"`
+	} `json:"ancestors,omitempty"`
+	Body struct {
+		Storage struct {
+			Value          string `json:"value"`
+			Representation string `json:"representation"`
+		} `json:"storage"`
+	} `json:"body"`
+}
+
+// Publish creates the page or updates it in place. In monthly mode the day is added
+// to the month's page, replacing that day's section when re-run. Version conflicts
+// from concurrent edits are retried against the latest version.
+func (c *ConfluencePublisher) Publish(ctx context.Context, data *models.ReportData) (int, error) {
+	page := c.cfg.Confluence
+	if page.URL == "" || page.SpaceKey == "" {
+		return 0, fmt.Errorf("confluence requires url and space_key")
+	}
+
+	content, err := c.generator.GenerateConfluence(data)
+	if err != nil {
+		return 0, err
+	}
+	title, err := pageTitle(page.Title, defaultPageTitle, data, page.Author)
+	if err != nil {
+		return 0, err
+	}
+
+	var merge func(existing string) string
+	if page.Monthly {
+		heading := "<h1>" + html.EscapeString(title) + "</h1>"
+		section := heading + "\n" + content
+		merge = func(existing string) string { return replaceDaySection(existing, heading, section) }
+		title, err = pageTitle(page.MonthlyTitle, defaultMonthlyTitle, data, page.Author)
+		if err != nil {
+			return 0, err
+		}
+	} else {
+		merge = func(string) string { return content }
+	}
+
+	err = retry(ctx, c.cfg.Retries, c.backoff, func() error {
+		return c.upsert(ctx, title, merge)
+	})
+	if err != nil {
+		return 0, err
+	}
+	return 1, nil
+}
+
+// upsert creates the page, or updates the latest version with the merged body
+func (c *ConfluencePublisher) upsert(ctx context.Context, title string, merge func(existing string) string) error {
+	existing, err := c.findPage(ctx, title)
+	if err != nil {
+		return err
+	}
+
+	page := confluencePage{Type: "page", Title: title}
+	page.Space.Key = c.cfg.Confluence.SpaceKey
+	page.Body.Storage.Representation = "storage"
+
+	if existing == nil {
+		page.Version.Number = 1
+		page.Body.Storage.Value = merge("")
+		if parent := c.cfg.Confluence.ParentID; parent != "" {
+			page.Ancestors = append(page.Ancestors, struct {
+				ID string `json:"id"`
+			}{parent})
+		}
+		return c.do(ctx, http.MethodPost, "/rest/api/content", page, nil)
+	}
+
+	page.ID = existing.ID
+	page.Version.Number = existing.Version.Number + 1
+	page.Body.Storage.Value = merge(existing.Body.Storage.Value)
+	if page.Body.Storage.Value == existing.Body.Storage.Value {
+		return nil
+	}
+	return c.do(ctx, http.MethodPut, "/rest/api/content/"+url.PathEscape(existing.ID), page, nil)
+}
+
+// findPage looks up a page by title in the configured space
+func (c *ConfluencePublisher) findPage(ctx context.Context, title string) (*confluencePage, error) {
+	query := url.Values{}
+	query.Set("spaceKey", c.cfg.Confluence.SpaceKey)
+	query.Set("title", title)
+	query.Set("type", "page")
+	query.Set("expand", "version,body.storage")
+
+	var result struct {
+		Results []confluencePage `json:"results"`
+	}
+	if err := c.do(ctx, http.MethodGet, "/rest/api/content?"+query.Encode(), nil, &result); err != nil {
+		return nil, err
+	}
+	if len(result.Results) == 0 {
+		return nil, nil
+	}
+	return &result.Results[0], nil
+}
+
+// do performs an authenticated REST request, marking conflicts and transient failures as retryable
+func (c *ConfluencePublisher) do(ctx context.Context, method, path string, in, out interface{}) error {
+	var body io.Reader
+	if in != nil {
+		raw, err := json.Marshal(in)
+		if err != nil {
+			return fmt.Errorf("failed to encode request: %w", err)
+		}
+		body = bytes.NewReader(raw)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, strings.TrimRight(c.cfg.Confluence.URL, "/")+path, body)
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	req.SetBasicAuth(c.cfg.Confluence.Username, c.cfg.Confluence.APIToken)
+	req.Header.Set("Accept", "application/json")
+	if in != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return &retryableError{fmt.Errorf("request failed: %w", err)}
+	}
+	defer resp.Body.Close()
+
+	respBody, _ := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+	switch {
+	case resp.StatusCode == http.StatusConflict:
+		return &retryableError{fmt.Errorf("version conflict: %s", strings.TrimSpace(string(respBody)))}
+	case resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= 500:
+		return &retryableError{fmt.Errorf("confluence returned %s: %s", resp.Status, strings.TrimSpace(string(respBody)))}
+	case resp.StatusCode < 200 || resp.StatusCode >= 300:
+		return fmt.Errorf("confluence returned %s: %s", resp.Status, strings.TrimSpace(string(respBody)))
+	}
+
+	if out != nil {
+		if err := json.Unmarshal(respBody, out); err != nil {
+			return fmt.Errorf("failed to parse confluence response: %w", err)
+		}
+	}
+	return nil
+}
+
+// pageTitle renders a title template with the report data and author
+func pageTitle(text, fallback string, data *models.ReportData, author string) (string, error) {
+	if text == "" {
+		text = fallback
+	}
+
+	tpl, err := template.New("title").Parse(text)
+	if err != nil {
+		return "", fmt.Errorf("invalid page title template: %w", err)
+	}
+	var sb strings.Builder
+	view := struct {
+		*models.ReportData
+		Author string
+	}{data, author}
+	if err := tpl.Execute(&sb, view); err != nil {
+		return "", fmt.Errorf("failed to render page title: %w", err)
+	}
+	return strings.TrimSpace(sb.String()), nil
+}
+
+// replaceDaySection replaces the section starting at heading up to the next <h1>,
+// or appends the section when the day is not on the page yet
+func replaceDaySection(existing, heading, section string) string {
+	start := strings.Index(existing, heading)
+	if start == -1 {
+		if existing == "" {
+			return section
+		}
+		return existing + "\n" + section
+	}
+
+	end := len(existing)
+	if next := strings.Index(existing[start+len(heading):], "<h1>"); next != -1 {
+		end = start + len(heading) + next
+	}
+	if rest := strings.TrimLeft(existing[end:], "\n"); rest != "" {
+		return existing[:start] + section + "\n" + rest
+	}
+	return existing[:start] + section
+}
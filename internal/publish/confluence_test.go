@@ -0,0 +1,218 @@
+package publish
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"daily_report/internal/config"
+	"daily_report/internal/report"
+	"daily_report/pkg/models"
+)
+
+// fakeConfluence keeps pages in memory and enforces optimistic versioning like the REST API
+type fakeConfluence struct {
+	mu        sync.Mutex
+	pages     map[string]*confluencePage
+	nextID    int
+	conflicts int // number of upcoming updates answered with 409 after a concurrent edit
+	puts      int
+}
+
+func newFakeConfluence(t *testing.T) (*fakeConfluence, *httptest.Server) {
+	f := &fakeConfluence{pages: make(map[string]*confluencePage), nextID: 100}
+	server := httptest.NewServer(http.HandlerFunc(f.handle))
+	t.Cleanup(server.Close)
+	return f, server
+}
+
+func (f *fakeConfluence) handle(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if user, pass, _ := r.BasicAuth(); user != "me" || pass != "token" {
+		http.Error(w, "unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	switch {
+	case r.Method == http.MethodGet && r.URL.Path == "/rest/api/content":
+		var results []confluencePage
+		for _, p := range f.pages {
+			if p.Title == r.URL.Query().Get("title") && p.Space.Key == r.URL.Query().Get("spaceKey") {
+				results = append(results, *p)
+			}
+		}
+		json.NewEncoder(w).Encode(map[string]interface{}{"results": results})
+
+	case r.Method == http.MethodPost && r.URL.Path == "/rest/api/content":
+		var p confluencePage
+		json.NewDecoder(r.Body).Decode(&p)
+		p.ID = strconv.Itoa(f.nextID)
+		f.nextID++
+		p.Version.Number = 1
+		f.pages[p.ID] = &p
+		json.NewEncoder(w).Encode(p)
+
+	case r.Method == http.MethodPut && strings.HasPrefix(r.URL.Path, "/rest/api/content/"):
+		f.puts++
+		id := strings.TrimPrefix(r.URL.Path, "/rest/api/content/")
+		current, ok := f.pages[id]
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+		if f.conflicts > 0 {
+			// Someone else edited the page in the meantime
+			f.conflicts--
+			current.Version.Number++
+			current.Body.Storage.Value += "\n<p>edited in browser</p>"
+		}
+		var p confluencePage
+		json.NewDecoder(r.Body).Decode(&p)
+		if p.Version.Number != current.Version.Number+1 {
+			http.Error(w, `{"message":"Version must be incremented on update"}`, http.StatusConflict)
+			return
+		}
+		p.Ancestors = current.Ancestors
+		f.pages[id] = &p
+		json.NewEncoder(w).Encode(p)
+
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+func (f *fakeConfluence) page(title string) *confluencePage {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	for _, p := range f.pages {
+		if p.Title == title {
+			return p
+		}
+	}
+	return nil
+}
+
+func newTestConfluencePublisher(serverURL string, monthly bool) *ConfluencePublisher {
+	p := NewConfluencePublisher(config.PublishConfig{
+		Name:    "wiki",
+		Type:    TypeConfluence,
+		Retries: 2,
+		Timeout: time.Second,
+		Confluence: config.ConfluencePublishConfig{
+			URL:      serverURL,
+			Username: "me",
+			APIToken: "token",
+			SpaceKey: "TEAM",
+			ParentID: "42",
+			Author:   "张三",
+			Monthly:  monthly,
+		},
+	}, report.NewGenerator())
+	p.backoff = time.Millisecond
+	return p
+}
+
+func TestConfluencePublisher_CreateAndUpdate(t *testing.T) {
+	fake, server := newFakeConfluence(t)
+	p := newTestConfluencePublisher(server.URL, false)
+
+	if _, err := p.Publish(context.Background(), testPublishData()); err != nil {
+		t.Fatalf("Publish failed: %v", err)
+	}
+	page := fake.page("日报 2026-10-18 张三")
+	if page == nil {
+		t.Fatal("Expected page to be created")
+	}
+	if len(page.Ancestors) != 1 || page.Ancestors[0].ID != "42" {
+		t.Errorf("Expected page under parent 42, got %+v", page.Ancestors)
+	}
+	if page.Body.Storage.Representation != "storage" || !strings.Contains(page.Body.Storage.Value, "fix: login timeout") {
+		t.Errorf("Unexpected body: %+v", page.Body.Storage)
+	}
+
+	data := testPublishData()
+	data.Items = append(data.Items, models.Item{Type: "git", Title: "feat: second commit", Time: data.Date,
+		Metadata: map[string]interface{}{"repo": "api", "commit": "1234567890"}})
+	if _, err := p.Publish(context.Background(), data); err != nil {
+		t.Fatalf("Publish failed: %v", err)
+	}
+	page = fake.page("日报 2026-10-18 张三")
+	if page.Version.Number != 2 || !strings.Contains(page.Body.Storage.Value, "feat: second commit") {
+		t.Errorf("Expected updated page at version 2, got version %d", page.Version.Number)
+	}
+}
+
+func TestConfluencePublisher_VersionConflict(t *testing.T) {
+	fake, server := newFakeConfluence(t)
+	p := newTestConfluencePublisher(server.URL, true)
+
+	if _, err := p.Publish(context.Background(), testPublishData()); err != nil {
+		t.Fatalf("Publish failed: %v", err)
+	}
+
+	fake.conflicts = 1
+	data := testPublishData()
+	data.Date = data.Date.AddDate(0, 0, 1)
+	if _, err := p.Publish(context.Background(), data); err != nil {
+		t.Fatalf("Publish failed after conflict: %v", err)
+	}
+
+	page := fake.page("日报 2026-10 张三")
+	if fake.puts != 2 {
+		t.Errorf("Expected the update to be retried once, got %d PUTs", fake.puts)
+	}
+	if page.Version.Number != 3 {
+		t.Errorf("Expected version 3, got %d", page.Version.Number)
+	}
+	if !strings.Contains(page.Body.Storage.Value, "edited in browser") {
+		t.Error("Concurrent edit must be preserved after the conflict")
+	}
+}
+
+func TestConfluencePublisher_Monthly(t *testing.T) {
+	fake, server := newFakeConfluence(t)
+	p := newTestConfluencePublisher(server.URL, true)
+
+	day1 := testPublishData()
+	day2 := testPublishData()
+	day2.Date = day1.Date.AddDate(0, 0, 1)
+	for _, data := range []*models.ReportData{day1, day2, day1} {
+		if _, err := p.Publish(context.Background(), data); err != nil {
+			t.Fatalf("Publish failed: %v", err)
+		}
+	}
+
+	page := fake.page("日报 2026-10 张三")
+	if page == nil {
+		t.Fatal("Expected monthly page to be created")
+	}
+	body := page.Body.Storage.Value
+	if strings.Count(body, "<h1>日报 2026-10-18 张三</h1>") != 1 || strings.Count(body, "<h1>日报 2026-10-19 张三</h1>") != 1 {
+		t.Errorf("Expected each day exactly once:\n%s", body)
+	}
+	if strings.Index(body, "2026-10-18") > strings.Index(body, "2026-10-19") {
+		t.Error("Re-publishing a day must keep its position")
+	}
+}
+
+func TestReplaceDaySection(t *testing.T) {
+	existing := "<h1>a</h1>\nold a\n<h1>b</h1>\nold b"
+
+	if got := replaceDaySection(existing, "<h1>a</h1>", "<h1>a</h1>\nnew a"); got != "<h1>a</h1>\nnew a\n<h1>b</h1>\nold b" {
+		t.Errorf("Unexpected replacement: %q", got)
+	}
+	if got := replaceDaySection(existing, "<h1>b</h1>", "<h1>b</h1>\nnew b"); got != "<h1>a</h1>\nold a\n<h1>b</h1>\nnew b" {
+		t.Errorf("Unexpected replacement: %q", got)
+	}
+	if got := replaceDaySection(existing, "<h1>c</h1>", "<h1>c</h1>\nc"); got != existing+"\n<h1>c</h1>\nc" {
+		t.Errorf("Unexpected append: %q", got)
+	}
+}
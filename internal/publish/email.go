@@ -0,0 +1,295 @@
+package publish
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"mime/multipart"
+	"mime/quotedprintable"
+	"net"
+	"net/smtp"
+	"net/textproto"
+	"strconv"
+	"strings"
+	"text/template"
+	"time"
+
+	"daily_report/internal/config"
+	"daily_report/internal/report"
+	"daily_report/pkg/models"
+)
+
+// TypeEmail sends the report over SMTP
+const TypeEmail = "email"
+
+// defaultEmailSubject is used when no subject template is configured
+const defaultEmailSubject = `日报 {{.Date.Format "2006-01-02"}}`
+
+// SMTP connection security modes
+const (
+	SecurityStartTLS = "starttls"
+	SecurityTLS      = "tls"
+	SecurityNone     = "none"
+)
+
+// EmailPublisher sends the report as a multipart email with text and HTML parts
+type EmailPublisher struct {
+	cfg       config.PublishConfig
+	generator *report.Generator
+	tlsConfig *tls.Config
+	backoff   time.Duration
+	now       func() time.Time
+}
+
+// NewEmailPublisher creates a new email publisher
+func NewEmailPublisher(cfg config.PublishConfig, generator *report.Generator) *EmailPublisher {
+	return &EmailPublisher{
+		cfg:       cfg,
+		generator: generator,
+		tlsConfig: &tls.Config{ServerName: cfg.Email.Host},
+		backoff:   defaultBackoff,
+		now:       time.Now,
+	}
+}
+
+// Name returns the configured target name
+func (e *EmailPublisher) Name() string {
+	return e.cfg.Name
+}
+
+// Publish renders the report and sends it to the configured recipients
+func (e *EmailPublisher) Publish(ctx context.Context, data *models.ReportData) (int, error) {
+	cfg := e.cfg.Email
+	if cfg.Host == "" || cfg.From == "" || len(cfg.To) == 0 {
+		return 0, fmt.Errorf("email requires host, from and at least one to address")
+	}
+
+	msg, err := e.buildMessage(data)
+	if err != nil {
+		return 0, err
+	}
+
+	recipients := append(append([]string{}, cfg.To...), cfg.Cc...)
+	err = retry(ctx, e.cfg.Retries, e.backoff, func() error {
+		return e.send(recipients, msg)
+	})
+	if err != nil {
+		return 0, err
+	}
+	return 1, nil
+}
+
+// buildMessage renders the subject and both bodies into a MIME message
+func (e *EmailPublisher) buildMessage(data *models.ReportData) ([]byte, error) {
+	cfg := e.cfg.Email
+
+	subject, err := renderSubject(cfg.Subject, data)
+	if err != nil {
+		return nil, err
+	}
+	text, err := e.generator.Generate(data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to render text body: %w", err)
+	}
+	html, err := e.generator.GenerateHTML(data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to render HTML body: %w", err)
+	}
+
+	var body bytes.Buffer
+	mw := multipart.NewWriter(&body)
+	for _, part := range []struct{ contentType, content string }{
+		{"text/plain; charset=UTF-8", text},
+		{"text/html; charset=UTF-8", html},
+	} {
+		w, err := mw.CreatePart(textproto.MIMEHeader{
+			"Content-Type":              {part.contentType},
+			"Content-Transfer-Encoding": {"quoted-printable"},
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to build email: %w", err)
+		}
+		qp := quotedprintable.NewWriter(w)
+		qp.Write([]byte(part.content))
+		qp.Close()
+	}
+	mw.Close()
+
+	var msg bytes.Buffer
+	headers := [][2]string{
+		{"From", cfg.From},
+		{"To", strings.Join(cfg.To, ", ")},
+		{"Cc", strings.Join(cfg.Cc, ", ")},
+		{"Subject", mime.QEncoding.Encode("UTF-8", subject)},
+		{"Date", e.now().Format(time.RFC1123Z)},
+		{"Message-ID", messageID(cfg.From)},
+		{"MIME-Version", "1.0"},
+		{"Content-Type", "multipart/alternative; boundary=" + mw.Boundary()},
+	}
+	for _, h := range headers {
+		if h[1] != "" {
+			fmt.Fprintf(&msg, "%s: %s\r\n", h[0], h[1])
+		}
+	}
+	msg.WriteString("\r\n")
+	msg.Write(body.Bytes())
+
+	return msg.Bytes(), nil
+}
+
+// send delivers the message in one SMTP session
+func (e *EmailPublisher) send(recipients []string, msg []byte) error {
+	cfg := e.cfg.Email
+
+	c, err := e.dial()
+	if err != nil {
+		return &retryableError{err}
+	}
+	defer c.Close()
+
+	if cfg.Username != "" {
+		if err := c.Auth(smtp.PlainAuth("", cfg.Username, cfg.Password, cfg.Host)); err != nil {
+			return smtpError("authentication failed", err)
+		}
+	}
+	if err := c.Mail(addressOf(cfg.From)); err != nil {
+		return smtpError("MAIL FROM rejected", err)
+	}
+	for _, rcpt := range recipients {
+		if err := c.Rcpt(addressOf(rcpt)); err != nil {
+			return smtpError(fmt.Sprintf("recipient %s rejected", rcpt), err)
+		}
+	}
+
+	w, err := c.Data()
+	if err != nil {
+		return smtpError("DATA rejected", err)
+	}
+	// Past DATA the server may already have queued the message, so a failure is
+	// never retried: a resend could deliver it twice
+	if _, err := w.Write(msg); err != nil {
+		return fmt.Errorf("failed to write message: %w", err)
+	}
+	if err := w.Close(); err != nil {
+		return fmt.Errorf("message may not have been delivered: %w", err)
+	}
+
+	// The message is accepted at this point; a failing QUIT must not cause a resend
+	c.Quit()
+	return nil
+}
+
+// dial connects to the server with the configured security mode
+func (e *EmailPublisher) dial() (*smtp.Client, error) {
+	cfg := e.cfg.Email
+
+	security := cfg.Security
+	if security == "" {
+		security = SecurityStartTLS
+	}
+	port := cfg.Port
+	if port == 0 {
+		switch security {
+		case SecurityTLS:
+			port = 465
+		case SecurityNone:
+			port = 25
+		default:
+			port = 587
+		}
+	}
+	addr := net.JoinHostPort(cfg.Host, strconv.Itoa(port))
+	dialer := &net.Dialer{Timeout: e.cfg.Timeout}
+
+	var conn net.Conn
+	var err error
+	switch security {
+	case SecurityTLS:
+		conn, err = tls.DialWithDialer(dialer, "tcp", addr, e.tlsConfig)
+	case SecurityStartTLS, SecurityNone:
+		conn, err = dialer.Dial("tcp", addr)
+	default:
+		return nil, fmt.Errorf("unsupported email security %q: must be starttls, tls or none", security)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to %s: %w", addr, err)
+	}
+	if e.cfg.Timeout > 0 {
+		conn.SetDeadline(time.Now().Add(e.cfg.Timeout))
+	}
+
+	c, err := smtp.NewClient(conn, cfg.Host)
+	if err != nil {
+		conn.Close()
+		return nil, fmt.Errorf("failed to start SMTP session: %w", err)
+	}
+	if security == SecurityStartTLS {
+		if err := c.StartTLS(e.tlsConfig); err != nil {
+			c.Close()
+			return nil, fmt.Errorf("STARTTLS failed: %w", err)
+		}
+	}
+
+	return c, nil
+}
+
+// smtpError wraps an SMTP failure, marking temporary replies and connection errors as retryable
+func smtpError(context string, err error) error {
+	wrapped := fmt.Errorf("%s: %w", context, err)
+
+	var reply *textproto.Error
+	if errors.As(err, &reply) {
+		if reply.Code >= 500 {
+			return wrapped
+		}
+		return &retryableError{wrapped}
+	}
+	var netErr net.Error
+	if errors.As(err, &netErr) || errors.Is(err, io.EOF) {
+		return &retryableError{wrapped}
+	}
+	return wrapped
+}
+
+// renderSubject executes the subject template over the report data
+func renderSubject(subject string, data *models.ReportData) (string, error) {
+	if subject == "" {
+		subject = defaultEmailSubject
+	}
+
+	tpl, err := template.New("subject").Parse(subject)
+	if err != nil {
+		return "", fmt.Errorf("invalid email subject template: %w", err)
+	}
+	var sb strings.Builder
+	if err := tpl.Execute(&sb, data); err != nil {
+		return "", fmt.Errorf("failed to render email subject: %w", err)
+	}
+	return strings.TrimSpace(sb.String()), nil
+}
+
+// addressOf extracts the bare address from "Name <addr>" forms
+func addressOf(s string) string {
+	if i := strings.LastIndex(s, "<"); i != -1 {
+		if j := strings.LastIndex(s, ">"); j > i {
+			return s[i+1 : j]
+		}
+	}
+	return strings.TrimSpace(s)
+}
+
+// messageID generates a unique Message-ID in the sender's domain
+func messageID(from string) string {
+	domain := "localhost"
+	if addr := addressOf(from); strings.Contains(addr, "@") {
+		domain = addr[strings.LastIndex(addr, "@")+1:]
+	}
+	buf := make([]byte, 12)
+	rand.Read(buf)
+	return fmt.Sprintf("<%x@%s>", buf, domain)
+}
@@ -0,0 +1,315 @@
+package publish
+
+import (
+	"bufio"
+	"context"
+	"crypto/tls"
+	"encoding/base64"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/mail"
+	"net/textproto"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"daily_report/internal/config"
+	"daily_report/internal/report"
+)
+
+// fakeMail is one message received by fakeSMTP
+type fakeMail struct {
+	auth string
+	tls  bool
+	from string
+	to   []string
+	data string
+}
+
+// fakeSMTP is a minimal SMTP server supporting AUTH PLAIN, STARTTLS and implicit TLS
+type fakeSMTP struct {
+	ln        net.Listener
+	tlsConfig *tls.Config
+	implicit  bool
+	hangUp    bool // Close the connection after receiving a message instead of replying
+
+	mu       sync.Mutex
+	sessions int
+	mails    []fakeMail
+}
+
+func newFakeSMTP(t *testing.T, implicit bool) (*fakeSMTP, *tls.Config) {
+	t.Helper()
+
+	// Borrow the test certificate of an httptest TLS server
+	certServer := httptest.NewTLSServer(http.NotFoundHandler())
+	t.Cleanup(certServer.Close)
+	serverTLS := &tls.Config{Certificates: certServer.TLS.Certificates}
+	clientTLS := &tls.Config{
+		RootCAs:    certServer.Client().Transport.(*http.Transport).TLSClientConfig.RootCAs,
+		ServerName: "127.0.0.1",
+	}
+
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if implicit {
+		ln = tls.NewListener(ln, serverTLS)
+	}
+	t.Cleanup(func() { ln.Close() })
+
+	s := &fakeSMTP{ln: ln, tlsConfig: serverTLS, implicit: implicit}
+	go func() {
+		for {
+			conn, err := ln.Accept()
+			if err != nil {
+				return
+			}
+			go s.serve(conn)
+		}
+	}()
+	return s, clientTLS
+}
+
+func (s *fakeSMTP) port() int {
+	return s.ln.Addr().(*net.TCPAddr).Port
+}
+
+func (s *fakeSMTP) received() []fakeMail {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]fakeMail{}, s.mails...)
+}
+
+func (s *fakeSMTP) sessionCount() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.sessions
+}
+
+func (s *fakeSMTP) serve(conn net.Conn) {
+	defer conn.Close()
+	s.mu.Lock()
+	s.sessions++
+	s.mu.Unlock()
+
+	tp := textproto.NewConn(conn)
+	mail := fakeMail{tls: s.implicit}
+	tp.PrintfLine("220 fake ESMTP")
+
+	for {
+		line, err := tp.ReadLine()
+		if err != nil {
+			return
+		}
+		verb, arg, _ := strings.Cut(line, " ")
+		switch strings.ToUpper(verb) {
+		case "EHLO", "HELO":
+			if !mail.tls {
+				tp.PrintfLine("250-fake")
+				tp.PrintfLine("250-STARTTLS")
+			} else {
+				tp.PrintfLine("250-fake")
+			}
+			tp.PrintfLine("250 AUTH PLAIN")
+		case "STARTTLS":
+			tp.PrintfLine("220 ready")
+			tlsConn := tls.Server(conn, s.tlsConfig)
+			if err := tlsConn.Handshake(); err != nil {
+				return
+			}
+			conn, tp, mail.tls = tlsConn, textproto.NewConn(tlsConn), true
+		case "AUTH":
+			_, encoded, _ := strings.Cut(arg, " ")
+			raw, _ := base64.StdEncoding.DecodeString(encoded)
+			mail.auth = string(raw)
+			tp.PrintfLine("235 ok")
+		case "MAIL":
+			mail.from = strings.Trim(strings.TrimPrefix(arg, "FROM:"), "<>")
+			tp.PrintfLine("250 ok")
+		case "RCPT":
+			rcpt := strings.Trim(strings.TrimPrefix(arg, "TO:"), "<>")
+			if strings.HasPrefix(rcpt, "reject") {
+				tp.PrintfLine("550 no such user")
+				continue
+			}
+			mail.to = append(mail.to, rcpt)
+			tp.PrintfLine("250 ok")
+		case "DATA":
+			tp.PrintfLine("354 go ahead")
+			data, _ := io.ReadAll(tp.DotReader())
+			mail.data = string(data)
+			s.mu.Lock()
+			s.mails = append(s.mails, mail)
+			s.mu.Unlock()
+			if s.hangUp {
+				return
+			}
+			tp.PrintfLine("250 queued")
+		case "QUIT":
+			tp.PrintfLine("221 bye")
+			return
+		default:
+			tp.PrintfLine("250 ok")
+		}
+	}
+}
+
+func newTestEmailPublisher(port int, security string, clientTLS *tls.Config) *EmailPublisher {
+	p := NewEmailPublisher(config.PublishConfig{
+		Name:    "mail",
+		Type:    TypeEmail,
+		Retries: 1,
+		Timeout: 5 * time.Second,
+		Email: config.EmailConfig{
+			Host:     "127.0.0.1",
+			Port:     port,
+			Security: security,
+			Username: "bot",
+			Password: "s3cret",
+			From:     "日报机器人 <bot@example.com>",
+			To:       []string{"boss@example.com"},
+			Cc:       []string{"team@example.com"},
+		},
+	}, report.NewGenerator())
+	p.tlsConfig = clientTLS
+	p.backoff = time.Millisecond
+	p.now = func() time.Time { return time.Date(2026, 10, 18, 20, 0, 0, 0, time.UTC) }
+	return p
+}
+
+func TestEmailPublisher_Multipart(t *testing.T) {
+	server, clientTLS := newFakeSMTP(t, false)
+
+	n, err := newTestEmailPublisher(server.port(), SecurityNone, clientTLS).Publish(context.Background(), testPublishData())
+	if err != nil {
+		t.Fatalf("Publish failed: %v", err)
+	}
+	if n != 1 {
+		t.Errorf("Expected 1 message, got %d", n)
+	}
+
+	mails := server.received()
+	if len(mails) != 1 {
+		t.Fatalf("Expected 1 mail, got %d", len(mails))
+	}
+	got := mails[0]
+	if got.auth != "\x00bot\x00s3cret" {
+		t.Errorf("Unexpected AUTH PLAIN credentials: %q", got.auth)
+	}
+	if got.from != "bot@example.com" || strings.Join(got.to, ",") != "boss@example.com,team@example.com" {
+		t.Errorf("Unexpected envelope: from %q to %v", got.from, got.to)
+	}
+
+	msg, err := mail.ReadMessage(strings.NewReader(got.data))
+	if err != nil {
+		t.Fatalf("Invalid message: %v", err)
+	}
+	subject, _ := new(mime.WordDecoder).DecodeHeader(msg.Header.Get("Subject"))
+	if subject != "日报 2026-10-18" {
+		t.Errorf("Expected default subject, got %q", subject)
+	}
+	if msg.Header.Get("Cc") != "team@example.com" {
+		t.Errorf("Expected Cc header, got %q", msg.Header.Get("Cc"))
+	}
+
+	mediaType, params, _ := mime.ParseMediaType(msg.Header.Get("Content-Type"))
+	if mediaType != "multipart/alternative" {
+		t.Fatalf("Expected multipart/alternative, got %q", mediaType)
+	}
+	mr := multipart.NewReader(msg.Body, params["boundary"])
+	var types []string
+	for {
+		part, err := mr.NextPart()
+		if err != nil {
+			break
+		}
+		body, _ := io.ReadAll(part)
+		types = append(types, strings.Split(part.Header.Get("Content-Type"), ";")[0])
+		if !strings.Contains(string(body), "fix: login timeout") {
+			t.Errorf("%s part is missing the commit", part.Header.Get("Content-Type"))
+		}
+	}
+	if strings.Join(types, ",") != "text/plain,text/html" {
+		t.Errorf("Unexpected parts: %v", types)
+	}
+}
+
+func TestEmailPublisher_TLSModes(t *testing.T) {
+	for _, security := range []string{SecurityStartTLS, SecurityTLS} {
+		t.Run(security, func(t *testing.T) {
+			server, clientTLS := newFakeSMTP(t, security == SecurityTLS)
+
+			if _, err := newTestEmailPublisher(server.port(), security, clientTLS).Publish(context.Background(), testPublishData()); err != nil {
+				t.Fatalf("Publish failed: %v", err)
+			}
+			mails := server.received()
+			if len(mails) != 1 || !mails[0].tls {
+				t.Errorf("Expected one mail over TLS, got %+v", mails)
+			}
+		})
+	}
+}
+
+func TestEmailPublisher_SubjectTemplate(t *testing.T) {
+	server, clientTLS := newFakeSMTP(t, false)
+
+	p := newTestEmailPublisher(server.port(), SecurityNone, clientTLS)
+	p.cfg.Email.Subject = `[Daily] {{.Date.Format "01/02"}} {{len .Items}} items`
+	if _, err := p.Publish(context.Background(), testPublishData()); err != nil {
+		t.Fatalf("Publish failed: %v", err)
+	}
+
+	msg, _ := mail.ReadMessage(bufio.NewReader(strings.NewReader(server.received()[0].data)))
+	if subject := msg.Header.Get("Subject"); subject != "[Daily] 10/18 1 items" {
+		t.Errorf("Unexpected subject %q", subject)
+	}
+}
+
+func TestEmailPublisher_RejectedRecipient(t *testing.T) {
+	server, clientTLS := newFakeSMTP(t, false)
+
+	p := newTestEmailPublisher(server.port(), SecurityNone, clientTLS)
+	p.cfg.Email.To = []string{"reject@example.com"}
+	_, err := p.Publish(context.Background(), testPublishData())
+	if err == nil || !strings.Contains(err.Error(), "550") {
+		t.Errorf("Expected 550 error, got %v", err)
+	}
+	if n := server.sessionCount(); n != 1 {
+		t.Errorf("Expected permanent failure without retry, got %d sessions", n)
+	}
+}
+
+func TestEmailPublisher_NoResendAfterData(t *testing.T) {
+	server, clientTLS := newFakeSMTP(t, false)
+	server.hangUp = true
+
+	_, err := newTestEmailPublisher(server.port(), SecurityNone, clientTLS).Publish(context.Background(), testPublishData())
+	if err == nil {
+		t.Fatal("Expected error when the final reply is missing")
+	}
+	if n := len(server.received()); n != 1 {
+		t.Errorf("Expected the message to be sent once, got %d", n)
+	}
+}
+
+func TestEmailPublisher_ConnectionRefused(t *testing.T) {
+	ln, _ := net.Listen("tcp", "127.0.0.1:0")
+	port := ln.Addr().(*net.TCPAddr).Port
+	ln.Close()
+
+	_, err := newTestEmailPublisher(port, SecurityNone, nil).Publish(context.Background(), testPublishData())
+	if err == nil || !strings.Contains(err.Error(), "giving up after 2 attempts") {
+		t.Errorf("Expected retries to be exhausted, got %v", err)
+	}
+	if !strings.Contains(err.Error(), "127.0.0.1:"+strconv.Itoa(port)) {
+		t.Errorf("Expected address in error, got %v", err)
+	}
+}
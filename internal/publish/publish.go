@@ -0,0 +1,131 @@
+package publish
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"daily_report/internal/config"
+	"daily_report/internal/report"
+	"daily_report/pkg/models"
+)
+
+// Publisher delivers a report to one destination
+type Publisher interface {
+	// Name returns the configured target name
+	Name() string
+	// Publish sends the report and returns the number of messages delivered
+	Publish(ctx context.Context, data *models.ReportData) (int, error)
+}
+
+// Result is the outcome of publishing to one target
+type Result struct {
+	Target   string
+	Messages int
+	Err      error
+}
+
+// New creates a publisher for the configured target type
+func New(cfg config.PublishConfig, generator *report.Generator) (Publisher, error) {
+	switch cfg.Type {
+	case TypeFeishu, TypeDingTalk, TypeWeCom, TypeSlack:
+		return NewWebhookPublisher(cfg, generator), nil
+	case TypeEmail:
+		return NewEmailPublisher(cfg, generator), nil
+	case TypeConfluence:
+		return NewConfluencePublisher(cfg, generator), nil
+	default:
+		return nil, fmt.Errorf("unsupported publish type %q for target %s", cfg.Type, cfg.Name)
+	}
+}
+
+// defaultBackoff is the delay before the first retry; it doubles on each attempt
+const defaultBackoff = time.Second
+
+// retryableError marks failures worth another attempt
+type retryableError struct {
+	err error
+}
+
+func (e *retryableError) Error() string { return e.err.Error() }
+func (e *retryableError) Unwrap() error { return e.err }
+
+// retry calls fn until it succeeds, fails permanently or runs out of retries,
+// waiting with exponential backoff between attempts
+func retry(ctx context.Context, retries int, backoff time.Duration, fn func() error) error {
+	var err error
+	for attempt := 0; attempt <= retries; attempt++ {
+		if attempt > 0 {
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case <-time.After(backoff << (attempt - 1)):
+			}
+		}
+
+		err = fn()
+		var retryable *retryableError
+		if err == nil || !errors.As(err, &retryable) {
+			return err
+		}
+	}
+	return fmt.Errorf("giving up after %d attempts: %w", retries+1, err)
+}
+
+// Select returns the configured targets with the given names, in the order requested
+func Select(targets []config.PublishConfig, names []string) ([]config.PublishConfig, error) {
+	byName := make(map[string]config.PublishConfig, len(targets))
+	for _, target := range targets {
+		byName[target.Name] = target
+	}
+
+	var selected []config.PublishConfig
+	for _, name := range names {
+		name = strings.TrimSpace(name)
+		if name == "" {
+			continue
+		}
+		target, ok := byName[name]
+		if !ok {
+			return nil, fmt.Errorf("unknown publish target %q", name)
+		}
+		selected = append(selected, target)
+	}
+
+	return selected, nil
+}
+
+// Run publishes the report to every publisher, continuing past failures
+func Run(ctx context.Context, publishers []Publisher, data *models.ReportData) []Result {
+	results := make([]Result, 0, len(publishers))
+	for _, p := range publishers {
+		n, err := p.Publish(ctx, data)
+		results = append(results, Result{Target: p.Name(), Messages: n, Err: err})
+	}
+	return results
+}
+
+// FormatSummary formats one status line per target
+func FormatSummary(results []Result) string {
+	var sb strings.Builder
+	for _, r := range results {
+		if r.Err != nil {
+			sb.WriteString(fmt.Sprintf("❌ %s: %v\n", r.Target, r.Err))
+		} else {
+			sb.WriteString(fmt.Sprintf("✅ %s: sent %d message(s)\n", r.Target, r.Messages))
+		}
+	}
+	return sb.String()
+}
+
+// Failed reports whether any target failed
+func Failed(results []Result) bool {
+	for _, r := range results {
+		if r.Err != nil {
+			return true
+		}
+	}
+	return false
+}
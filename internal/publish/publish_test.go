@@ -0,0 +1,64 @@
+package publish
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"daily_report/internal/config"
+	"daily_report/internal/report"
+	"daily_report/pkg/models"
+)
+
+type fakePublisher struct {
+	name string
+	err  error
+}
+
+func (f *fakePublisher) Name() string { return f.name }
+
+func (f *fakePublisher) Publish(ctx context.Context, data *models.ReportData) (int, error) {
+	if f.err != nil {
+		return 0, f.err
+	}
+	return 1, nil
+}
+
+func TestNew_UnsupportedType(t *testing.T) {
+	if _, err := New(config.PublishConfig{Name: "x", Type: "fax"}, report.NewGenerator()); err == nil {
+		t.Error("Expected error for unsupported type, got nil")
+	}
+}
+
+func TestSelect(t *testing.T) {
+	targets := []config.PublishConfig{{Name: "team", Type: TypeFeishu}, {Name: "slack", Type: TypeSlack}}
+
+	selected, err := Select(targets, []string{"slack", " team"})
+	if err != nil {
+		t.Fatalf("Select failed: %v", err)
+	}
+	if len(selected) != 2 || selected[0].Name != "slack" || selected[1].Name != "team" {
+		t.Errorf("Unexpected selection: %+v", selected)
+	}
+
+	if _, err := Select(targets, []string{"unknown"}); err == nil {
+		t.Error("Expected error for unknown target, got nil")
+	}
+}
+
+func TestRun_Summary(t *testing.T) {
+	results := Run(context.Background(), []Publisher{
+		&fakePublisher{name: "ok"},
+		&fakePublisher{name: "broken", err: errors.New("boom")},
+	}, &models.ReportData{})
+
+	if !Failed(results) {
+		t.Error("Expected failure to be reported")
+	}
+
+	summary := FormatSummary(results)
+	if !strings.Contains(summary, "✅ ok: sent 1 message(s)") || !strings.Contains(summary, "❌ broken: boom") {
+		t.Errorf("Unexpected summary:\n%s", summary)
+	}
+}
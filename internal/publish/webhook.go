@@ -0,0 +1,226 @@
+package publish
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptrace"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"daily_report/internal/config"
+	"daily_report/internal/report"
+	"daily_report/pkg/models"
+)
+
+// Webhook target types
+const (
+	TypeFeishu   = "feishu"
+	TypeDingTalk = "dingtalk"
+	TypeWeCom    = "wecom"
+	TypeSlack    = "slack"
+)
+
+// WebhookPublisher posts report cards to a chat bot webhook
+type WebhookPublisher struct {
+	cfg        config.PublishConfig
+	generator  *report.Generator
+	httpClient *http.Client
+	backoff    time.Duration
+	now        func() time.Time
+}
+
+// NewWebhookPublisher creates a new webhook publisher
+func NewWebhookPublisher(cfg config.PublishConfig, generator *report.Generator) *WebhookPublisher {
+	return &WebhookPublisher{
+		cfg:        cfg,
+		generator:  generator,
+		httpClient: &http.Client{Timeout: cfg.Timeout},
+		backoff:    defaultBackoff,
+		now:        time.Now,
+	}
+}
+
+// Name returns the configured target name
+func (w *WebhookPublisher) Name() string {
+	return w.cfg.Name
+}
+
+// Publish renders the report for the target platform and posts each message
+func (w *WebhookPublisher) Publish(ctx context.Context, data *models.ReportData) (int, error) {
+	if w.cfg.WebhookURL == "" {
+		return 0, fmt.Errorf("missing webhook_url")
+	}
+
+	messages, err := w.render(data)
+	if err != nil {
+		return 0, err
+	}
+
+	for i, msg := range messages {
+		if err := w.post(ctx, msg); err != nil {
+			return i, fmt.Errorf("failed to send message %d/%d: %w", i+1, len(messages), err)
+		}
+	}
+
+	return len(messages), nil
+}
+
+// render converts the report into platform payloads
+func (w *WebhookPublisher) render(data *models.ReportData) ([][]byte, error) {
+	opts := report.CardOptions{ReportURL: w.cfg.ReportURL}
+	switch w.cfg.Type {
+	case TypeFeishu:
+		return w.generator.FeishuCards(data, opts)
+	case TypeDingTalk:
+		return w.generator.DingTalkMessages(data, opts)
+	case TypeWeCom:
+		return w.generator.WeComMessages(data, opts)
+	case TypeSlack:
+		return w.generator.SlackMessages(data, opts)
+	default:
+		return nil, fmt.Errorf("unsupported webhook type %q", w.cfg.Type)
+	}
+}
+
+// post sends one message, retrying transient failures
+func (w *WebhookPublisher) post(ctx context.Context, msg []byte) error {
+	return retry(ctx, w.cfg.Retries, w.backoff, func() error {
+		return w.send(ctx, msg)
+	})
+}
+
+// send performs a single webhook request
+func (w *WebhookPublisher) send(ctx context.Context, msg []byte) error {
+	target, body, err := w.sign(msg)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, target, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	// Once the request is written the platform may already have delivered the
+	// message, so only failures before that point are retried
+	var written bool
+	req = req.WithContext(httptrace.WithClientTrace(ctx, &httptrace.ClientTrace{
+		WroteRequest: func(info httptrace.WroteRequestInfo) { written = info.Err == nil },
+	}))
+
+	resp, err := w.httpClient.Do(req)
+	if err != nil {
+		if !written {
+			return &retryableError{fmt.Errorf("request failed: %w", err)}
+		}
+		return fmt.Errorf("message may not have been delivered: %w", err)
+	}
+	defer resp.Body.Close()
+
+	respBody, _ := io.ReadAll(io.LimitReader(resp.Body, 64*1024))
+	if resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= 500 {
+		return &retryableError{fmt.Errorf("webhook returned %s: %s", resp.Status, strings.TrimSpace(string(respBody)))}
+	}
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("webhook returned %s: %s", resp.Status, strings.TrimSpace(string(respBody)))
+	}
+
+	return checkResponse(w.cfg.Type, respBody)
+}
+
+// sign applies the platform signature when a secret is configured
+func (w *WebhookPublisher) sign(msg []byte) (string, []byte, error) {
+	if w.cfg.Secret == "" {
+		return w.cfg.WebhookURL, msg, nil
+	}
+
+	switch w.cfg.Type {
+	case TypeDingTalk:
+		// DingTalk signs "timestamp\nsecret" with the secret and expects it in the query
+		timestamp := strconv.FormatInt(w.now().UnixMilli(), 10)
+		mac := hmac.New(sha256.New, []byte(w.cfg.Secret))
+		mac.Write([]byte(timestamp + "\n" + w.cfg.Secret))
+
+		u, err := url.Parse(w.cfg.WebhookURL)
+		if err != nil {
+			return "", nil, fmt.Errorf("invalid webhook_url: %w", err)
+		}
+		query := u.Query()
+		query.Set("timestamp", timestamp)
+		query.Set("sign", base64.StdEncoding.EncodeToString(mac.Sum(nil)))
+		u.RawQuery = query.Encode()
+		return u.String(), msg, nil
+
+	case TypeFeishu:
+		// Feishu uses "timestamp\nsecret" as the key over an empty message and expects it in the body
+		timestamp := strconv.FormatInt(w.now().Unix(), 10)
+		mac := hmac.New(sha256.New, []byte(timestamp+"\n"+w.cfg.Secret))
+
+		var payload map[string]json.RawMessage
+		if err := json.Unmarshal(msg, &payload); err != nil {
+			return "", nil, fmt.Errorf("failed to sign message: %w", err)
+		}
+		payload["timestamp"], _ = json.Marshal(timestamp)
+		payload["sign"], _ = json.Marshal(base64.StdEncoding.EncodeToString(mac.Sum(nil)))
+		body, err := json.Marshal(payload)
+		if err != nil {
+			return "", nil, fmt.Errorf("failed to sign message: %w", err)
+		}
+		return w.cfg.WebhookURL, body, nil
+
+	default:
+		return "", nil, fmt.Errorf("signing is not supported for %s webhooks", w.cfg.Type)
+	}
+}
+
+// rateLimitCodes lists the error codes each platform returns in a 200 response when throttling
+var rateLimitCodes = map[string]map[int]bool{
+	TypeFeishu:   {9499: true, 11232: true},
+	TypeDingTalk: {130101: true, 410100: true},
+	TypeWeCom:    {45009: true, 45033: true},
+}
+
+// checkResponse detects errors reported in a successful HTTP response body
+func checkResponse(typ string, body []byte) error {
+	if typ == TypeSlack {
+		if text := strings.TrimSpace(string(body)); text != "ok" {
+			return fmt.Errorf("slack returned %q", text)
+		}
+		return nil
+	}
+
+	var result struct {
+		Code    *int   `json:"code"`
+		Msg     string `json:"msg"`
+		ErrCode *int   `json:"errcode"`
+		ErrMsg  string `json:"errmsg"`
+	}
+	if err := json.Unmarshal(body, &result); err != nil {
+		return fmt.Errorf("failed to parse webhook response: %w", err)
+	}
+	code, msg := 0, ""
+	if result.Code != nil && *result.Code != 0 {
+		code, msg = *result.Code, result.Msg
+	} else if result.ErrCode != nil && *result.ErrCode != 0 {
+		code, msg = *result.ErrCode, result.ErrMsg
+	}
+	if code == 0 {
+		return nil
+	}
+
+	err := fmt.Errorf("webhook error %d: %s", code, msg)
+	if rateLimitCodes[typ][code] {
+		return &retryableError{err}
+	}
+	return err
+}
@@ -0,0 +1,270 @@
+package publish
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"daily_report/internal/config"
+	"daily_report/internal/report"
+	"daily_report/pkg/models"
+)
+
+func testPublishData() *models.ReportData {
+	date := time.Date(2026, 10, 18, 9, 0, 0, 0, time.UTC)
+	return &models.ReportData{
+		Date: date,
+		Items: []models.Item{
+			{Type: "git", Title: "fix: login timeout", Time: date, Metadata: map[string]interface{}{"repo": "api", "commit": "abcdef123456"}},
+		},
+		SourceStatus: map[string]models.SourceStatus{"git": {Name: "git", Success: true}},
+	}
+}
+
+func newTestPublisher(typ, webhookURL string) *WebhookPublisher {
+	p := NewWebhookPublisher(config.PublishConfig{
+		Name:       "test",
+		Type:       typ,
+		WebhookURL: webhookURL,
+		Retries:    2,
+		Timeout:    time.Second,
+	}, report.NewGenerator())
+	p.backoff = time.Millisecond
+	p.now = func() time.Time { return time.Unix(1760000000, 0) }
+	return p
+}
+
+func TestWebhookPublisher_Platforms(t *testing.T) {
+	tests := []struct {
+		typ      string
+		response string
+		field    string
+	}{
+		{TypeFeishu, `{"code":0,"msg":"success"}`, `"msg_type":"interactive"`},
+		{TypeDingTalk, `{"errcode":0,"errmsg":"ok"}`, `"msgtype":"markdown"`},
+		{TypeWeCom, `{"errcode":0,"errmsg":"ok"}`, `"msgtype":"markdown"`},
+		{TypeSlack, `ok`, `"blocks"`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.typ, func(t *testing.T) {
+			var body string
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				raw, _ := io.ReadAll(r.Body)
+				body = string(raw)
+				if r.Header.Get("Content-Type") != "application/json" {
+					t.Errorf("Expected JSON content type, got %q", r.Header.Get("Content-Type"))
+				}
+				w.Write([]byte(tt.response))
+			}))
+			defer server.Close()
+
+			n, err := newTestPublisher(tt.typ, server.URL).Publish(context.Background(), testPublishData())
+			if err != nil {
+				t.Fatalf("Publish failed: %v", err)
+			}
+			if n != 1 {
+				t.Errorf("Expected 1 message, got %d", n)
+			}
+			if !strings.Contains(body, tt.field) || !strings.Contains(body, "fix: login timeout") {
+				t.Errorf("Unexpected payload: %s", body)
+			}
+		})
+	}
+}
+
+func TestWebhookPublisher_DingTalkSignature(t *testing.T) {
+	var timestamp, sign string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		timestamp = r.URL.Query().Get("timestamp")
+		sign = r.URL.Query().Get("sign")
+		if r.URL.Query().Get("access_token") != "abc" {
+			t.Errorf("Expected access_token to be kept, got %q", r.URL.RawQuery)
+		}
+		w.Write([]byte(`{"errcode":0}`))
+	}))
+	defer server.Close()
+
+	p := newTestPublisher(TypeDingTalk, server.URL+"/robot/send?access_token=abc")
+	p.cfg.Secret = "SECxyz"
+	if _, err := p.Publish(context.Background(), testPublishData()); err != nil {
+		t.Fatalf("Publish failed: %v", err)
+	}
+
+	if timestamp != "1760000000000" {
+		t.Errorf("Expected millisecond timestamp, got %q", timestamp)
+	}
+	mac := hmac.New(sha256.New, []byte("SECxyz"))
+	mac.Write([]byte(timestamp + "\nSECxyz"))
+	if want := base64.StdEncoding.EncodeToString(mac.Sum(nil)); sign != want {
+		t.Errorf("Expected sign %q, got %q", want, sign)
+	}
+}
+
+func TestWebhookPublisher_FeishuSignature(t *testing.T) {
+	var payload struct {
+		Timestamp string `json:"timestamp"`
+		Sign      string `json:"sign"`
+		MsgType   string `json:"msg_type"`
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		json.NewDecoder(r.Body).Decode(&payload)
+		w.Write([]byte(`{"code":0}`))
+	}))
+	defer server.Close()
+
+	p := newTestPublisher(TypeFeishu, server.URL)
+	p.cfg.Secret = "secret"
+	if _, err := p.Publish(context.Background(), testPublishData()); err != nil {
+		t.Fatalf("Publish failed: %v", err)
+	}
+
+	mac := hmac.New(sha256.New, []byte("1760000000\nsecret"))
+	if payload.Timestamp != "1760000000" || payload.Sign != base64.StdEncoding.EncodeToString(mac.Sum(nil)) {
+		t.Errorf("Unexpected signature fields: %+v", payload)
+	}
+	if payload.MsgType != "interactive" {
+		t.Errorf("Expected card fields to be kept, got %+v", payload)
+	}
+}
+
+func TestWebhookPublisher_RetriesTransientErrors(t *testing.T) {
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&calls, 1) < 3 {
+			http.Error(w, "busy", http.StatusServiceUnavailable)
+			return
+		}
+		w.Write([]byte("ok"))
+	}))
+	defer server.Close()
+
+	if _, err := newTestPublisher(TypeSlack, server.URL).Publish(context.Background(), testPublishData()); err != nil {
+		t.Fatalf("Publish failed: %v", err)
+	}
+	if calls != 3 {
+		t.Errorf("Expected 3 attempts, got %d", calls)
+	}
+}
+
+func TestWebhookPublisher_GivesUp(t *testing.T) {
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		http.Error(w, "down", http.StatusBadGateway)
+	}))
+	defer server.Close()
+
+	_, err := newTestPublisher(TypeSlack, server.URL).Publish(context.Background(), testPublishData())
+	if err == nil || !strings.Contains(err.Error(), "giving up after 3 attempts") {
+		t.Errorf("Expected give-up error, got %v", err)
+	}
+	if calls != 3 {
+		t.Errorf("Expected 3 attempts, got %d", calls)
+	}
+}
+
+func TestWebhookPublisher_RetriesRateLimitCodes(t *testing.T) {
+	tests := []struct {
+		name string
+		typ  string
+		body string
+		ok   string
+	}{
+		{"feishu", TypeFeishu, `{"code":9499,"msg":"too many request"}`, `{"code":0}`},
+		{"dingtalk", TypeDingTalk, `{"errcode":130101,"errmsg":"send too fast"}`, `{"errcode":0}`},
+		{"wecom", TypeWeCom, `{"errcode":45009,"errmsg":"api freq out of limit"}`, `{"errcode":0}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var calls int32
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if atomic.AddInt32(&calls, 1) == 1 {
+					w.Write([]byte(tt.body))
+					return
+				}
+				w.Write([]byte(tt.ok))
+			}))
+			defer server.Close()
+
+			if _, err := newTestPublisher(tt.typ, server.URL).Publish(context.Background(), testPublishData()); err != nil {
+				t.Fatalf("Publish failed: %v", err)
+			}
+			if calls != 2 {
+				t.Errorf("Expected 2 attempts, got %d", calls)
+			}
+		})
+	}
+}
+
+func TestWebhookPublisher_RetriesConnectionErrors(t *testing.T) {
+	server := httptest.NewServer(http.NotFoundHandler())
+	server.Close()
+
+	_, err := newTestPublisher(TypeSlack, server.URL).Publish(context.Background(), testPublishData())
+	if err == nil || !strings.Contains(err.Error(), "giving up after 3 attempts") {
+		t.Errorf("Expected retries before giving up, got %v", err)
+	}
+}
+
+func TestWebhookPublisher_NoRetryAfterRequestSent(t *testing.T) {
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		io.ReadAll(r.Body)
+		conn, _, err := w.(http.Hijacker).Hijack()
+		if err == nil {
+			conn.Close()
+		}
+	}))
+	defer server.Close()
+
+	if _, err := newTestPublisher(TypeSlack, server.URL).Publish(context.Background(), testPublishData()); err == nil {
+		t.Error("Expected error for a dropped connection")
+	}
+	if calls != 1 {
+		t.Errorf("Expected no resend once the request was written, got %d attempts", calls)
+	}
+}
+
+func TestWebhookPublisher_PermanentErrors(t *testing.T) {
+	tests := []struct {
+		name   string
+		typ    string
+		status int
+		body   string
+	}{
+		{"bad request", TypeSlack, http.StatusBadRequest, "invalid_payload"},
+		{"feishu code", TypeFeishu, http.StatusOK, `{"code":19021,"msg":"sign match fail"}`},
+		{"dingtalk errcode", TypeDingTalk, http.StatusOK, `{"errcode":310000,"errmsg":"keywords not in content"}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var calls int32
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				atomic.AddInt32(&calls, 1)
+				w.WriteHeader(tt.status)
+				w.Write([]byte(tt.body))
+			}))
+			defer server.Close()
+
+			if _, err := newTestPublisher(tt.typ, server.URL).Publish(context.Background(), testPublishData()); err == nil {
+				t.Error("Expected error, got nil")
+			}
+			if calls != 1 {
+				t.Errorf("Expected no retries, got %d attempts", calls)
+			}
+		})
+	}
+}
@@ -0,0 +1,167 @@
+package report
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"unicode/utf8"
+
+	"daily_report/pkg/models"
+)
+
+// CardOptions controls chat card rendering
+type CardOptions struct {
+	// ReportURL adds a button linking to the full report when set
+	ReportURL string
+	// MaxBytes overrides the platform message size limit
+	MaxBytes int
+}
+
+// cardTitle returns the title shown on chat cards
+func (g *Generator) cardTitle(data *models.ReportData) string {
+	return g.reportTitle(data, "2006-01-02", "2006-01")
+}
+
+// cardSections returns visible sections that have items
+func (g *Generator) cardSections(data *models.ReportData) (all []Section, nonEmpty []Section) {
+	all = g.buildSections(g.groupItemsByType(data.Items))
+	for _, s := range all {
+		if len(s.Items) > 0 {
+			nonEmpty = append(nonEmpty, s)
+		}
+	}
+	return all, nonEmpty
+}
+
+// cardMarkup describes the inline Markdown dialect of a chat platform
+type cardMarkup struct {
+	bold   func(text string) string
+	link   func(title, url string) string
+	escape func(text string) string
+}
+
+// markdownMarkup is standard Markdown as understood by Feishu, DingTalk and Teams
+var markdownMarkup = cardMarkup{
+	bold:   func(text string) string { return "**" + text + "**" },
+	link:   func(title, url string) string { return fmt.Sprintf("[%s](%s)", title, url) },
+	escape: func(text string) string { return text },
+}
+
+// cardSectionLines renders a section as lines in the given markup
+func (g *Generator) cardSectionLines(s Section, m cardMarkup) []string {
+	var lines []string
+
+	if s.Type == "git" {
+		byRepo := groupBy("repo", s.Items)
+		repos := make([]string, 0, len(byRepo))
+		for repo := range byRepo {
+			repos = append(repos, repo)
+		}
+		sort.Strings(repos)
+
+		for _, repo := range repos {
+			lines = append(lines, m.bold(m.escape(repo)))
+			for _, item := range byRepo[repo] {
+				line := fmt.Sprintf("- %s %s", m.escape(item.Title), g.clock(item.Time))
+				if hash := meta("commit", item); hash != "" {
+					line += " " + shortHash(hash)
+				}
+				lines = append(lines, line)
+			}
+		}
+		return lines
+	}
+
+	for _, item := range s.Items {
+		title := m.escape(item.Title)
+		if isURL(item.Link) {
+			title = m.link(title, item.Link)
+		}
+		line := fmt.Sprintf("- %s %s", title, g.clock(item.Time))
+		if status := meta("status", item); status != "" {
+			line += fmt.Sprintf(" (%s)", m.escape(status))
+		}
+		lines = append(lines, line)
+	}
+	return lines
+}
+
+// sourceStatusLine summarizes collection status in one line
+func sourceStatusLine(status map[string]models.SourceStatus) string {
+	names := make([]string, 0, len(status))
+	for name := range status {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	parts := make([]string, 0, len(names))
+	for _, name := range names {
+		if status[name].Success {
+			parts = append(parts, "✅ "+name)
+		} else {
+			parts = append(parts, "❌ "+name)
+		}
+	}
+	return "数据源: " + strings.Join(parts, " | ")
+}
+
+// chunkLines splits lines into chunks whose joined size is at most maxBytes;
+// a single line longer than maxBytes is truncated
+func chunkLines(lines []string, maxBytes int) [][]string {
+	var chunks [][]string
+	var current []string
+	size := 0
+
+	for _, line := range lines {
+		line = truncateBytes(line, maxBytes)
+		if len(current) > 0 && size+len(line)+1 > maxBytes {
+			chunks = append(chunks, current)
+			current, size = nil, 0
+		}
+		current = append(current, line)
+		size += len(line) + 1
+	}
+	if len(current) > 0 {
+		chunks = append(chunks, current)
+	}
+
+	return chunks
+}
+
+// pagedTitle appends a page counter when a report is split into several messages
+func pagedTitle(title string, page, pages int) string {
+	if pages <= 1 {
+		return title
+	}
+	return fmt.Sprintf("%s (%d/%d)", title, page, pages)
+}
+
+// truncateBytes shortens s to at most maxBytes bytes on a rune boundary, appending an ellipsis when cut
+func truncateBytes(s string, maxBytes int) string {
+	if len(s) <= maxBytes {
+		return s
+	}
+
+	const ellipsis = "…"
+	cut := maxBytes - len(ellipsis)
+	if cut < 0 {
+		cut = 0
+	}
+	for cut > 0 && !utf8.RuneStart(s[cut]) {
+		cut--
+	}
+	return s[:cut] + ellipsis
+}
+
+// marshalPayload encodes a chat payload without escaping HTML characters
+func marshalPayload(v interface{}) ([]byte, error) {
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	enc.SetEscapeHTML(false)
+	if err := enc.Encode(v); err != nil {
+		return nil, err
+	}
+	return bytes.TrimRight(buf.Bytes(), "\n"), nil
+}
@@ -0,0 +1,247 @@
+package report
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+	"unicode/utf8"
+
+	"daily_report/pkg/models"
+)
+
+var update = flag.Bool("update", false, "update golden files")
+
+func testCardData() *models.ReportData {
+	data := testTextData()
+	data.Items = append(data.Items,
+		models.Item{Type: "meeting", Title: "周会", Time: data.Date.Add(2 * time.Hour), Link: "https://meeting.example.com/m/1"},
+		models.Item{Type: "confluence", Title: "设计文档", Time: data.Date.Add(3 * time.Hour), Link: "https://wiki.example.com/p/2"},
+	)
+	data.SourceStatus = map[string]models.SourceStatus{
+		"git":  {Name: "git", Success: true},
+		"jira": {Name: "jira", Success: false, Error: "timeout"},
+	}
+	return data
+}
+
+// assertGolden compares messages with a golden file, rewriting it with -update
+func assertGolden(t *testing.T, name string, messages [][]byte) {
+	t.Helper()
+
+	var buf bytes.Buffer
+	buf.WriteString("[\n")
+	for i, msg := range messages {
+		var indented bytes.Buffer
+		if err := json.Indent(&indented, msg, "  ", "  "); err != nil {
+			t.Fatalf("message %d is not valid JSON: %v", i, err)
+		}
+		buf.WriteString("  ")
+		buf.Write(indented.Bytes())
+		if i < len(messages)-1 {
+			buf.WriteString(",")
+		}
+		buf.WriteString("\n")
+	}
+	buf.WriteString("]\n")
+
+	path := filepath.Join("testdata", name)
+	if *update {
+		if err := os.WriteFile(path, buf.Bytes(), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	want, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("failed to read golden file (run with -update to create): %v", err)
+	}
+	if !bytes.Equal(buf.Bytes(), want) {
+		t.Errorf("%s mismatch (run with -update to accept):\n%s", name, buf.String())
+	}
+}
+
+// assertPaged checks that a long report is split into messages within the limit
+func assertPaged(t *testing.T, messages [][]byte, maxBytes int) {
+	t.Helper()
+
+	if len(messages) < 2 {
+		t.Fatalf("Expected report to be split, got %d message(s)", len(messages))
+	}
+	for i, msg := range messages {
+		if len(msg) > maxBytes {
+			t.Errorf("message %d has %d bytes, limit %d", i, len(msg), maxBytes)
+		}
+		if !strings.Contains(string(msg), fmt.Sprintf("(%d/%d)", i+1, len(messages))) {
+			t.Errorf("message %d is missing its page counter", i)
+		}
+	}
+}
+
+func longCardData() *models.ReportData {
+	data := testCardData()
+	for i := 0; i < 200; i++ {
+		data.Items = append(data.Items, models.Item{
+			Type:     "git",
+			Title:    fmt.Sprintf("chore: 第 %d 次提交，整理配置与文档", i),
+			Time:     data.Date.Add(time.Duration(i) * time.Minute),
+			Metadata: map[string]interface{}{"repo": fmt.Sprintf("repo-%d", i%3), "commit": "0123456789"},
+		})
+	}
+	return data
+}
+
+func TestFeishuCards_Golden(t *testing.T) {
+	messages, err := NewGenerator().FeishuCards(testCardData(), CardOptions{ReportURL: "https://wiki.example.com/daily/2026-10-18"})
+	if err != nil {
+		t.Fatalf("FeishuCards failed: %v", err)
+	}
+	assertGolden(t, "feishu_card.golden.json", messages)
+}
+
+func TestFeishuCards_Split(t *testing.T) {
+	messages, err := NewGenerator().FeishuCards(longCardData(), CardOptions{MaxBytes: 8000})
+	if err != nil {
+		t.Fatalf("FeishuCards failed: %v", err)
+	}
+	assertPaged(t, messages, 8000)
+}
+
+func TestDingTalkMessages_Golden(t *testing.T) {
+	messages, err := NewGenerator().DingTalkMessages(testCardData(), CardOptions{})
+	if err != nil {
+		t.Fatalf("DingTalkMessages failed: %v", err)
+	}
+	assertGolden(t, "dingtalk_markdown.golden.json", messages)
+
+	messages, err = NewGenerator().DingTalkMessages(testCardData(), CardOptions{ReportURL: "https://wiki.example.com/daily/2026-10-18"})
+	if err != nil {
+		t.Fatalf("DingTalkMessages failed: %v", err)
+	}
+	assertGolden(t, "dingtalk_actioncard.golden.json", messages)
+}
+
+func TestDingTalkMessages_Split(t *testing.T) {
+	messages, err := NewGenerator().DingTalkMessages(longCardData(), CardOptions{MaxBytes: 4000})
+	if err != nil {
+		t.Fatalf("DingTalkMessages failed: %v", err)
+	}
+	assertPaged(t, messages, 4000)
+}
+
+func TestChunkLines(t *testing.T) {
+	chunks := chunkLines([]string{"aaaa", "bbbb", "cccc", strings.Repeat("长", 10)}, 10)
+
+	if len(chunks) != 3 {
+		t.Fatalf("Expected 3 chunks, got %v", chunks)
+	}
+	for _, chunk := range chunks {
+		if size := len(strings.Join(chunk, "\n")); size > 10 {
+			t.Errorf("chunk %v has %d bytes", chunk, size)
+		}
+	}
+}
+
+func TestSlackMessages_Golden(t *testing.T) {
+	messages, err := NewGenerator().SlackMessages(testCardData(), CardOptions{ReportURL: "https://wiki.example.com/daily/2026-10-18"})
+	if err != nil {
+		t.Fatalf("SlackMessages failed: %v", err)
+	}
+	assertGolden(t, "slack_blocks.golden.json", messages)
+}
+
+func TestSlackMessages_Limits(t *testing.T) {
+	data := longCardData()
+	for i := 0; i < 60; i++ {
+		data.Items = append(data.Items, models.Item{
+			Type:  fmt.Sprintf("source%02d", i),
+			Title: "<script> & more",
+			Time:  data.Date,
+		})
+	}
+
+	messages, err := NewGenerator().SlackMessages(data, CardOptions{})
+	if err != nil {
+		t.Fatalf("SlackMessages failed: %v", err)
+	}
+	if len(messages) < 2 {
+		t.Fatalf("Expected report to be split, got %d message(s)", len(messages))
+	}
+
+	for i, msg := range messages {
+		var payload struct {
+			Blocks []struct {
+				Type string `json:"type"`
+				Text struct {
+					Text string `json:"text"`
+				} `json:"text"`
+			} `json:"blocks"`
+		}
+		if err := json.Unmarshal(msg, &payload); err != nil {
+			t.Fatalf("message %d is not valid JSON: %v", i, err)
+		}
+		if len(payload.Blocks) > slackMaxBlocks {
+			t.Errorf("message %d has %d blocks", i, len(payload.Blocks))
+		}
+		for _, block := range payload.Blocks {
+			if block.Type == "section" && utf8.RuneCountInString(block.Text.Text) > slackMaxTextChars {
+				t.Errorf("message %d has a section with %d characters", i, utf8.RuneCountInString(block.Text.Text))
+			}
+			if strings.Contains(block.Text.Text, "<script>") {
+				t.Errorf("message %d contains unescaped text: %q", i, block.Text.Text)
+			}
+		}
+	}
+}
+
+func TestTeamsCards_Golden(t *testing.T) {
+	messages, err := NewGenerator().TeamsCards(testCardData(), CardOptions{ReportURL: "https://wiki.example.com/daily/2026-10-18"})
+	if err != nil {
+		t.Fatalf("TeamsCards failed: %v", err)
+	}
+	assertGolden(t, "teams_card.golden.json", messages)
+}
+
+func TestTeamsCards_Split(t *testing.T) {
+	messages, err := NewGenerator().TeamsCards(longCardData(), CardOptions{MaxBytes: 8000})
+	if err != nil {
+		t.Fatalf("TeamsCards failed: %v", err)
+	}
+	assertPaged(t, messages, 8000)
+}
+
+func TestWeComMessages_Golden(t *testing.T) {
+	messages, err := NewGenerator().WeComMessages(testCardData(), CardOptions{ReportURL: "https://wiki.example.com/daily/2026-10-18"})
+	if err != nil {
+		t.Fatalf("WeComMessages failed: %v", err)
+	}
+	assertGolden(t, "wecom_markdown.golden.json", messages)
+}
+
+func TestWeComMessages_Split(t *testing.T) {
+	messages, err := NewGenerator().WeComMessages(longCardData(), CardOptions{})
+	if err != nil {
+		t.Fatalf("WeComMessages failed: %v", err)
+	}
+	if len(messages) < 2 {
+		t.Fatalf("Expected report to be split, got %d message(s)", len(messages))
+	}
+	for i, msg := range messages {
+		var payload struct {
+			Markdown struct {
+				Content string `json:"content"`
+			} `json:"markdown"`
+		}
+		if err := json.Unmarshal(msg, &payload); err != nil {
+			t.Fatalf("message %d is not valid JSON: %v", i, err)
+		}
+		if len(payload.Markdown.Content) > weComMaxBytes {
+			t.Errorf("message %d content has %d bytes", i, len(payload.Markdown.Content))
+		}
+	}
+}
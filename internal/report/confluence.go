@@ -0,0 +1,21 @@
+package report
+
+import (
+	_ "embed"
+
+	"daily_report/pkg/models"
+)
+
+// FormatConfluence renders Confluence storage format
+const FormatConfluence = "confluence"
+
+// confluenceTemplate is the built-in Confluence storage format template
+//
+//go:embed html/confluence.storage.tmpl
+var confluenceTemplate string
+
+// GenerateConfluence generates the report body in Confluence storage format (XHTML),
+// with per-repository commit lists in expand macros
+func (g *Generator) GenerateConfluence(data *models.ReportData) (string, error) {
+	return g.renderHTML("confluence storage", confluenceTemplate, data)
+}
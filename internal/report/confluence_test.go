@@ -0,0 +1,47 @@
+package report
+
+import (
+	"encoding/xml"
+	"io"
+	"strings"
+	"testing"
+
+	"daily_report/pkg/models"
+)
+
+func TestGenerateConfluence(t *testing.T) {
+	data := testGoTemplateData()
+	data.Items = append(data.Items, models.Item{Type: "jira", Title: "PROJ-1 <b> & co", Time: data.Date,
+		Link: "https://jira.example.com/browse/PROJ-1", Metadata: map[string]interface{}{"status": "Done"}})
+	data.SourceStatus = map[string]models.SourceStatus{"git": {Name: "git", Success: true}}
+
+	result, err := NewGenerator().GenerateConfluence(data)
+	if err != nil {
+		t.Fatalf("GenerateConfluence failed: %v", err)
+	}
+
+	for _, want := range []string{
+		"<h2>💻 代码提交</h2>",
+		`<ac:structured-macro ac:name="expand"><ac:parameter ac:name="title">api (2)</ac:parameter>`,
+		"<code>abc1234</code>",
+		`<a href="https://jira.example.com/browse/PROJ-1">PROJ-1 &lt;b&gt; &amp; co</a>`,
+		"✅ git",
+	} {
+		if !strings.Contains(result, want) {
+			t.Errorf("Expected storage format to contain %q\n%s", want, result)
+		}
+	}
+	if strings.Contains(result, "<style") || strings.Contains(result, "<html") {
+		t.Error("Storage format must be a body fragment")
+	}
+
+	// Storage format must be well-formed XML
+	dec := xml.NewDecoder(strings.NewReader("<root>" + result + "</root>"))
+	for {
+		if _, err := dec.Token(); err == io.EOF {
+			break
+		} else if err != nil {
+			t.Fatalf("Storage format is not well-formed: %v\n%s", err, result)
+		}
+	}
+}
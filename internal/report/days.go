@@ -0,0 +1,118 @@
+package report
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"daily_report/internal/timeutil"
+	"daily_report/pkg/models"
+)
+
+// Day is the rendering view of one calendar day of a multi-day report
+type Day struct {
+	Date     time.Time
+	Total    int
+	Sections []Section
+}
+
+// Summary returns the non-zero section counts of the day, e.g. "Git 提交 3 次 · 会议 1 场"
+func (d Day) Summary() string {
+	var parts []string
+	for _, s := range d.Sections {
+		if s.Count == 0 {
+			continue
+		}
+		part := fmt.Sprintf("%s %d", s.Label, s.Count)
+		if s.Unit != "" {
+			part += " " + s.Unit
+		}
+		parts = append(parts, part)
+	}
+	if len(parts) == 0 {
+		return "无工作产出"
+	}
+	return strings.Join(parts, " · ")
+}
+
+// SetSkipEmptyDays omits days without items from per-day sections
+func (g *Generator) SetSkipEmptyDays(skip bool) {
+	g.skipEmptyDays = skip
+}
+
+// isMultiDay reports whether the report range spans more than one workday
+func (g *Generator) isMultiDay(data *models.ReportData) bool {
+	if data.StartTime.IsZero() || data.EndTime.IsZero() {
+		return false
+	}
+	loc := g.reportLocation(data)
+	return workday(data, data.StartTime, loc) != workday(data, data.EndTime, loc)
+}
+
+// reportTitle names the report by its period, e.g. "日报 - 2026-10-18", "日报 - 2026-10-12 至 2026-10-14",
+// "周报 - 2026-10-12 至 2026-10-18" or "月报 - 2026-10", formatting dates in the report timezone
+func (g *Generator) reportTitle(data *models.ReportData, dayLayout, monthLayout string) string {
+	if data.StartTime.IsZero() || data.EndTime.IsZero() {
+		return fmt.Sprintf("日报 - %s", g.formatDate(dayLayout, data.Date))
+	}
+
+	loc := g.reportLocation(data)
+	start, end := workday(data, data.StartTime, loc), workday(data, data.EndTime, loc)
+	switch {
+	case data.Period == models.PeriodMonth:
+		return fmt.Sprintf("月报 - %s", start.Format(monthLayout))
+	case data.Period == models.PeriodWeek:
+		return fmt.Sprintf("周报 - %s 至 %s", start.Format(dayLayout), end.Format(dayLayout))
+	case start != end:
+		return fmt.Sprintf("日报 - %s 至 %s", start.Format(dayLayout), end.Format(dayLayout))
+	default:
+		return fmt.Sprintf("日报 - %s", start.Format(dayLayout))
+	}
+}
+
+// buildDays splits the items into workdays of the report range in the
+// location of data.StartTime, oldest first
+func (g *Generator) buildDays(data *models.ReportData) []Day {
+	if data.StartTime.IsZero() || data.EndTime.IsZero() {
+		return nil
+	}
+	loc := g.reportLocation(data)
+
+	byDay := make(map[time.Time][]models.Item)
+	for _, item := range data.Items {
+		key := workday(data, item.Time, loc)
+		byDay[key] = append(byDay[key], item)
+	}
+
+	var days []Day
+	last := workday(data, data.EndTime, loc)
+	for day := workday(data, data.StartTime, loc); !day.After(last); day = day.AddDate(0, 0, 1) {
+		items := byDay[day]
+		if len(items) == 0 && g.skipEmptyDays {
+			continue
+		}
+		days = append(days, Day{
+			Date:     day,
+			Total:    len(items),
+			Sections: g.buildSections(g.groupItemsByType(items)),
+		})
+	}
+
+	sort.SliceStable(days, func(i, j int) bool { return days[i].Date.Before(days[j].Date) })
+	return days
+}
+
+// workday returns midnight of the workday containing t in loc, honoring the day start recorded in data
+func workday(data *models.ReportData, t time.Time, loc *time.Location) time.Time {
+	dayStart, _ := timeutil.ParseClock(data.Meta.DayStart)
+	return timeutil.WorkdayDate(t.In(loc), dayStart)
+}
+
+// weekdayNames are the Chinese weekday names indexed by time.Weekday
+var weekdayNames = [...]string{"周日", "周一", "周二", "周三", "周四", "周五", "周六"}
+
+// weekday returns the Chinese weekday name of t
+func weekday(t time.Time) string {
+	return weekdayNames[t.Weekday()]
+}
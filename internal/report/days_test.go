@@ -0,0 +1,147 @@
+package report
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"daily_report/pkg/models"
+)
+
+func multiDayData() *models.ReportData {
+	loc := time.FixedZone("CST", 8*3600)
+	start := time.Date(2026, 10, 12, 0, 0, 0, 0, loc)
+	return &models.ReportData{
+		Date:      start,
+		StartTime: start,
+		EndTime:   time.Date(2026, 10, 14, 23, 59, 59, 0, loc),
+		Items: []models.Item{
+			{Type: "git", Title: "feat: a", Time: start.Add(10 * time.Hour), Metadata: map[string]interface{}{"repo": "api"}},
+			// 2026-10-14 01:00 in CST although it is still 10-13 in UTC
+			{Type: "meeting", Title: "Standup", Time: time.Date(2026, 10, 13, 17, 0, 0, 0, time.UTC)},
+		},
+		SourceStatus: map[string]models.SourceStatus{"git": {Name: "git", Success: true}},
+	}
+}
+
+func TestBuildDays(t *testing.T) {
+	gen := NewGenerator()
+	days := gen.buildDays(multiDayData())
+
+	if len(days) != 3 {
+		t.Fatalf("Expected 3 days, got %d", len(days))
+	}
+	if days[0].Total != 1 || days[1].Total != 0 || days[2].Total != 1 {
+		t.Errorf("Unexpected day totals: %d, %d, %d", days[0].Total, days[1].Total, days[2].Total)
+	}
+	if got := days[2].Date.Format("2006-01-02"); got != "2026-10-14" {
+		t.Errorf("Expected meeting on 2026-10-14 in report timezone, got %s", got)
+	}
+	if got := days[0].Summary(); got != "Git 提交 1 次" {
+		t.Errorf("Unexpected summary: %q", got)
+	}
+	if got := days[1].Summary(); got != "无工作产出" {
+		t.Errorf("Unexpected empty summary: %q", got)
+	}
+
+	gen.SetSkipEmptyDays(true)
+	if days := gen.buildDays(multiDayData()); len(days) != 2 {
+		t.Errorf("Expected empty day to be skipped, got %d days", len(days))
+	}
+}
+
+func TestIsMultiDay(t *testing.T) {
+	data := multiDayData()
+	if !NewGenerator().isMultiDay(data) {
+		t.Error("Expected three-day range to be multi-day")
+	}
+	data.EndTime = data.StartTime.Add(23 * time.Hour)
+	if NewGenerator().isMultiDay(data) {
+		t.Error("Expected single-day range not to be multi-day")
+	}
+}
+
+func TestGenerate_MultiDay(t *testing.T) {
+	gen := NewGenerator()
+	gen.SetSkipEmptyDays(true)
+
+	result, err := gen.Generate(multiDayData())
+	if err != nil {
+		t.Fatalf("Generate failed: %v", err)
+	}
+
+	for _, want := range []string{"# 日报 - 2026年10月12日 至 2026年10月14日", "## 📆 2026年10月12日 周一", "## 📆 2026年10月14日 周三", "会议 1 场", "Standup"} {
+		if !strings.Contains(result, want) {
+			t.Errorf("Expected output to contain %q, got:\n%s", want, result)
+		}
+	}
+	if strings.Contains(result, "2026年10月13日") {
+		t.Error("Empty day should be skipped")
+	}
+	if strings.Index(result, "### api") > strings.Index(result, "Standup") {
+		t.Error("Days should be rendered in order")
+	}
+}
+
+func TestBuildDays_DayStart(t *testing.T) {
+	data := multiDayData()
+	data.Meta.DayStart = "04:00"
+	data.StartTime = data.StartTime.Add(4 * time.Hour)
+	data.EndTime = data.EndTime.Add(4 * time.Hour)
+
+	days := NewGenerator().buildDays(data)
+	if len(days) != 3 {
+		t.Fatalf("Expected 3 days, got %d", len(days))
+	}
+	// The 01:00 meeting on 10-14 belongs to the 10-13 workday
+	if days[1].Total != 1 || days[2].Total != 0 {
+		t.Errorf("Expected meeting on the previous workday, got totals %d, %d", days[1].Total, days[2].Total)
+	}
+}
+
+func TestReportTitle(t *testing.T) {
+	loc := time.FixedZone("CST", 8*3600)
+	day := time.Date(2026, 10, 12, 0, 0, 0, 0, loc)
+
+	tests := []struct {
+		name   string
+		period string
+		start  time.Time
+		end    time.Time
+		want   string
+	}{
+		{"No range", "", time.Time{}, time.Time{}, "日报 - 2026-10-12"},
+		{"Day", models.PeriodDay, day, day.Add(24*time.Hour - time.Second), "日报 - 2026-10-12"},
+		{"Multi-day", "", day, day.AddDate(0, 0, 3).Add(-time.Second), "日报 - 2026-10-12 至 2026-10-14"},
+		{"Week", models.PeriodWeek, day, day.AddDate(0, 0, 7).Add(-time.Second), "周报 - 2026-10-12 至 2026-10-18"},
+		{"Month", models.PeriodMonth, time.Date(2026, 10, 1, 0, 0, 0, 0, loc), time.Date(2026, 10, 31, 23, 59, 59, 0, loc), "月报 - 2026-10"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data := &models.ReportData{Date: day, Period: tt.period, StartTime: tt.start, EndTime: tt.end}
+			if got := NewGenerator().reportTitle(data, "2006-01-02", "2006-01"); got != tt.want {
+				t.Errorf("Expected %q, got %q", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestReportTitle_Renderers(t *testing.T) {
+	data := multiDayData()
+	data.Period = models.PeriodWeek
+	data.EndTime = data.StartTime.AddDate(0, 0, 7).Add(-time.Second)
+	gen := NewGenerator()
+
+	html, err := gen.GenerateHTML(data)
+	if err != nil {
+		t.Fatalf("GenerateHTML failed: %v", err)
+	}
+	if !strings.Contains(html, "<h1>周报 - 2026年10月12日 至 2026年10月18日</h1>") {
+		t.Errorf("Expected weekly HTML title, got:\n%s", html)
+	}
+
+	if text := gen.GenerateText(data, 0); !strings.HasPrefix(text, "周报 - 2026-10-12 至 2026-10-18\n") {
+		t.Errorf("Expected weekly text title, got:\n%s", text)
+	}
+}
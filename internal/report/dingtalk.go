@@ -0,0 +1,118 @@
+package report
+
+import (
+	"fmt"
+	"strings"
+
+	"daily_report/pkg/models"
+)
+
+// FormatDingTalk renders DingTalk robot ActionCard or markdown messages
+const FormatDingTalk = "dingtalk"
+
+// dingTalkMaxBytes is the DingTalk robot message size limit
+const dingTalkMaxBytes = 20000
+
+// DingTalkMessages renders the report as one or more DingTalk robot messages.
+// An ActionCard with a button is used when a report URL is set, a markdown message otherwise.
+func (g *Generator) DingTalkMessages(data *models.ReportData, opts CardOptions) ([][]byte, error) {
+	maxBytes := opts.MaxBytes
+	if maxBytes <= 0 {
+		maxBytes = dingTalkMaxBytes
+	}
+
+	all, sections := g.cardSections(data)
+	title := g.cardTitle(data)
+
+	var stats []string
+	for _, s := range all {
+		stats = append(stats, fmt.Sprintf("%s **%d**", s.Label, s.Count))
+	}
+
+	footer := "---\n\n" + sourceStatusLine(data.SourceStatus)
+
+	// Every page repeats the title; the stats line opens the first page
+	blocks := []string{strings.Join(stats, " · ")}
+	for _, s := range sections {
+		chunks := chunkLines(g.cardSectionLines(s, markdownMarkup), maxBytes/2)
+		for i, chunk := range chunks {
+			heading := fmt.Sprintf("#### %s (%d)", s.Heading, s.Count)
+			if i > 0 {
+				heading = fmt.Sprintf("#### %s (续)", s.Heading)
+			}
+			blocks = append(blocks, heading+"\n\n"+strings.Join(chunk, "\n"))
+		}
+	}
+
+	var pages [][]string
+	current := []string{}
+	for _, block := range blocks {
+		candidate := append(append([]string{}, current...), block)
+		size, err := dingTalkSize(title+" (99/99)", dingTalkText(title+" (99/99)", candidate, footer), opts.ReportURL)
+		if err != nil {
+			return nil, err
+		}
+		if size > maxBytes && len(current) > 0 {
+			pages = append(pages, current)
+			current = []string{block}
+			continue
+		}
+		current = candidate
+	}
+	pages = append(pages, current)
+
+	messages := make([][]byte, 0, len(pages))
+	for i, page := range pages {
+		pageTitle := pagedTitle(title, i+1, len(pages))
+		pageFooter := ""
+		if i == len(pages)-1 {
+			pageFooter = footer
+		}
+		msg, err := marshalPayload(dingTalkMessage(pageTitle, dingTalkText(pageTitle, page, pageFooter), opts.ReportURL))
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode DingTalk message: %w", err)
+		}
+		messages = append(messages, msg)
+	}
+
+	return messages, nil
+}
+
+// dingTalkText joins the title, blocks and optional footer into message Markdown
+func dingTalkText(title string, blocks []string, footer string) string {
+	parts := append([]string{"### " + title}, blocks...)
+	if footer != "" {
+		parts = append(parts, footer)
+	}
+	return strings.Join(parts, "\n\n")
+}
+
+// dingTalkMessage builds a robot webhook payload
+func dingTalkMessage(title, text, reportURL string) map[string]interface{} {
+	if reportURL == "" {
+		return map[string]interface{}{
+			"msgtype":  "markdown",
+			"markdown": map[string]string{"title": title, "text": text},
+		}
+	}
+
+	return map[string]interface{}{
+		"msgtype": "actionCard",
+		"actionCard": map[string]string{
+			"title":          title,
+			"text":           text,
+			"btnOrientation": "0",
+			"singleTitle":    "查看完整日报",
+			"singleURL":      reportURL,
+		},
+	}
+}
+
+// dingTalkSize returns the encoded size of a message
+func dingTalkSize(title, text, reportURL string) (int, error) {
+	msg, err := marshalPayload(dingTalkMessage(title, text, reportURL))
+	if err != nil {
+		return 0, fmt.Errorf("failed to encode DingTalk message: %w", err)
+	}
+	return len(msg), nil
+}
@@ -0,0 +1,106 @@
+package report
+
+import (
+	"bytes"
+	"fmt"
+	"regexp"
+	"text/template"
+
+	"daily_report/pkg/models"
+)
+
+// Template engines
+const (
+	// EngineAuto picks the engine by inspecting the template
+	EngineAuto = "auto"
+	// EngineLegacy substitutes fixed {{placeholder}} names
+	EngineLegacy = "legacy"
+	// EngineGo renders the template with text/template
+	EngineGo = "go"
+)
+
+var (
+	// actionPattern matches any {{...}} action
+	actionPattern = regexp.MustCompile(`\{\{(.*?)\}\}`)
+	// legacyPlaceholderPattern matches the body of a legacy placeholder such as git_section
+	legacyPlaceholderPattern = regexp.MustCompile(`^[a-z_]+$`)
+)
+
+// DetectEngine reports which engine a template is written for.
+// Templates whose actions are all bare lower-case names are legacy templates.
+func DetectEngine(tmpl string) string {
+	for _, match := range actionPattern.FindAllStringSubmatch(tmpl, -1) {
+		if !legacyPlaceholderPattern.MatchString(match[1]) {
+			return EngineGo
+		}
+	}
+	return EngineLegacy
+}
+
+// resolveEngine returns the engine to use for the given template
+func (g *Generator) resolveEngine(tmpl string) string {
+	switch g.engine {
+	case EngineLegacy, EngineGo:
+		return g.engine
+	default:
+		return DetectEngine(tmpl)
+	}
+}
+
+// renderGoTemplate renders a text/template with the report data as root object
+func (g *Generator) renderGoTemplate(data *models.ReportData, itemsByType map[string][]models.Item, stats map[string]int, tpl *Template) (string, error) {
+	name := tpl.Name
+	if name == "" {
+		name = "report"
+	}
+
+	t, err := g.newGoTemplate(name, data, itemsByType).Parse(tpl.Main)
+	if err != nil {
+		return "", fmt.Errorf("failed to parse template: %w", err)
+	}
+
+	// Partials may invoke or redefine blocks declared by the main template
+	for _, p := range tpl.Partials {
+		if _, err := t.New(p.Name).Parse(p.Source); err != nil {
+			return "", fmt.Errorf("failed to parse template %s: %w", p.Name, err)
+		}
+	}
+
+	// Expose the grouped and sorted view without mutating the caller's data
+	view := *data
+	view.ItemsByType = itemsByType
+	view.Stats = stats
+
+	var buf bytes.Buffer
+	if err := t.Execute(&buf, &view); err != nil {
+		return "", fmt.Errorf("failed to render template: %w", err)
+	}
+
+	return buf.String(), nil
+}
+
+// newGoTemplate creates an empty root template with the function library installed.
+// The render function executes a template named after the section type when one is
+// defined, so templates can override individual sections with define or block.
+func (g *Generator) newGoTemplate(name string, data *models.ReportData, itemsByType map[string][]models.Item) *template.Template {
+	t := template.New(name)
+
+	funcs := g.templateFuncs(data, itemsByType)
+	funcs["render"] = func(s Section) (string, error) {
+		if override := t.Lookup(s.Type); override != nil && override != t {
+			var buf bytes.Buffer
+			if err := override.Execute(&buf, s); err != nil {
+				return "", err
+			}
+			return buf.String(), nil
+		}
+		return g.renderSection(s.Type, s.Items), nil
+	}
+	t.Funcs(funcs)
+
+	if g.strict {
+		t.Option("missingkey=error")
+	}
+
+	return t
+}
@@ -0,0 +1,94 @@
+package report
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"daily_report/pkg/models"
+)
+
+func TestDetectEngine(t *testing.T) {
+	tests := []struct {
+		name     string
+		template string
+		expected string
+	}{
+		{"Plain text", "# Report", EngineLegacy},
+		{"Legacy placeholders", "# {{date}}\n\n{{git_section}}", EngineLegacy},
+		{"Field access", "# {{.Date}}", EngineGo},
+		{"Range", "{{range .Items}}- {{.Title}}\n{{end}}", EngineGo},
+		{"Function call", "{{ now }}", EngineGo},
+		{"Mixed", "{{date}} {{if .Items}}x{{end}}", EngineGo},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := DetectEngine(tt.template); got != tt.expected {
+				t.Errorf("DetectEngine() = %s, want %s", got, tt.expected)
+			}
+		})
+	}
+}
+
+func testGoTemplateData() *models.ReportData {
+	base := time.Date(2026, 10, 18, 9, 0, 0, 0, time.UTC)
+	return &models.ReportData{
+		Date: base,
+		Items: []models.Item{
+			{Type: "git", Title: "feat: a", Time: base, Metadata: map[string]interface{}{"repo": "api", "commit": "abc1234567"}},
+			{Type: "git", Title: "fix: b", Time: base.Add(time.Hour), Metadata: map[string]interface{}{"repo": "web", "commit": "def1234567"}},
+			{Type: "git", Title: "docs: c", Time: base.Add(2 * time.Hour), Metadata: map[string]interface{}{"repo": "api", "commit": "fed1234567"}},
+		},
+		SourceStatus: map[string]models.SourceStatus{"git": {Name: "git", Success: true}},
+	}
+}
+
+func TestGenerator_GoTemplate(t *testing.T) {
+	tmpl := `# {{.Date | formatDate "2006-01-02"}}
+{{range $repo, $commits := groupBy "repo" (index .ItemsByType "git")}}{{$repo}}: {{len $commits}} {{plural (len $commits) "commit" "commits"}}
+{{end}}{{if not (index .ItemsByType "meeting")}}no meetings{{end}}`
+
+	gen := NewGeneratorWithTemplate(tmpl)
+	result, err := gen.Generate(testGoTemplateData())
+	if err != nil {
+		t.Fatalf("Generate failed: %v", err)
+	}
+
+	expected := "# 2026-10-18\napi: 2 commits\nweb: 1 commit\nno meetings"
+	if result != expected {
+		t.Errorf("Unexpected output:\n%s\nwant:\n%s", result, expected)
+	}
+}
+
+func TestGenerator_GoTemplateSection(t *testing.T) {
+	gen := NewGeneratorWithTemplate(`{{section "git"}}{{sourceStatus}}`)
+
+	result, err := gen.Generate(testGoTemplateData())
+	if err != nil {
+		t.Fatalf("Generate failed: %v", err)
+	}
+
+	if !strings.Contains(result, "## 💻 代码提交") || !strings.Contains(result, "✅ git") {
+		t.Errorf("Expected legacy git section and source status, got:\n%s", result)
+	}
+}
+
+func TestGenerator_GoTemplateParseError(t *testing.T) {
+	gen := NewGeneratorWithTemplate(`{{range .Items}}`)
+
+	if _, err := gen.Generate(testGoTemplateData()); err == nil {
+		t.Error("Expected parse error for unterminated range")
+	}
+}
+
+func TestGenerator_ExplicitLegacyEngine(t *testing.T) {
+	gen := NewGeneratorWithTemplate(`{{.Date}} {{git_count}}`)
+	gen.SetEngine(EngineLegacy)
+
+	// Go actions are not evaluated but reported as unknown legacy placeholders
+	_, err := gen.Generate(testGoTemplateData())
+	if err == nil || !strings.Contains(err.Error(), "unknown placeholder {{.Date}}") {
+		t.Errorf("Expected unknown placeholder error, got %v", err)
+	}
+}
@@ -0,0 +1,102 @@
+package report
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+
+	"gopkg.in/yaml.v3"
+
+	"daily_report/pkg/models"
+)
+
+// Output formats
+const (
+	FormatMarkdown = "markdown"
+	FormatJSON     = "json"
+	FormatYAML     = "yaml"
+)
+
+// EncodeJSON encodes report data as indented JSON
+func EncodeJSON(data *models.ReportData) ([]byte, error) {
+	out, err := json.MarshalIndent(data, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode report data as JSON: %w", err)
+	}
+	return append(out, '\n'), nil
+}
+
+// EncodeYAML encodes report data as YAML using the same field names as the JSON form
+func EncodeYAML(data *models.ReportData) ([]byte, error) {
+	raw, err := json.Marshal(data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode report data: %w", err)
+	}
+
+	var generic interface{}
+	if err := json.Unmarshal(raw, &generic); err != nil {
+		return nil, fmt.Errorf("failed to encode report data: %w", err)
+	}
+
+	var buf bytes.Buffer
+	enc := yaml.NewEncoder(&buf)
+	enc.SetIndent(2)
+	if err := enc.Encode(generic); err != nil {
+		return nil, fmt.Errorf("failed to encode report data as YAML: %w", err)
+	}
+	if err := enc.Close(); err != nil {
+		return nil, fmt.Errorf("failed to encode report data as YAML: %w", err)
+	}
+
+	return buf.Bytes(), nil
+}
+
+// DecodeReportData parses report data previously written as JSON or YAML.
+// Derived fields (items_by_type, stats) are rebuilt from items when absent.
+func DecodeReportData(raw []byte) (*models.ReportData, error) {
+	trimmed := bytes.TrimSpace(raw)
+
+	// JSON is a subset of YAML, but decode it directly to keep error positions precise
+	if !bytes.HasPrefix(trimmed, []byte("{")) {
+		var generic interface{}
+		if err := yaml.Unmarshal(raw, &generic); err != nil {
+			return nil, fmt.Errorf("failed to parse report data: %w", err)
+		}
+		converted, err := json.Marshal(generic)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse report data: %w", err)
+		}
+		trimmed = converted
+	}
+
+	var data models.ReportData
+	if err := json.Unmarshal(trimmed, &data); err != nil {
+		return nil, fmt.Errorf("failed to parse report data: %w", err)
+	}
+
+	switch data.Meta.SchemaVersion {
+	case models.SchemaVersion:
+	case "":
+		return nil, fmt.Errorf("missing meta.schema_version in report data")
+	default:
+		return nil, fmt.Errorf("unsupported report data schema version %q (supported: %s)", data.Meta.SchemaVersion, models.SchemaVersion)
+	}
+
+	if data.ItemsByType == nil {
+		data.ItemsByType = make(map[string][]models.Item)
+		for _, item := range data.Items {
+			data.ItemsByType[item.Type] = append(data.ItemsByType[item.Type], item)
+		}
+	}
+	if data.Stats == nil {
+		data.Stats = make(map[string]int)
+		for typ, items := range data.ItemsByType {
+			data.Stats[typ] = len(items)
+		}
+	}
+	if data.SourceStatus == nil {
+		data.SourceStatus = make(map[string]models.SourceStatus)
+	}
+
+	return &data, nil
+}
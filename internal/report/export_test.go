@@ -0,0 +1,130 @@
+package report
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"daily_report/pkg/models"
+)
+
+func testExportData() *models.ReportData {
+	data := testGoTemplateData()
+	data.StartTime = data.Date
+	data.EndTime = data.Date.Add(24*time.Hour - time.Nanosecond)
+	data.ItemsByType = map[string][]models.Item{"git": data.Items}
+	data.Stats = map[string]int{"git": len(data.Items)}
+	data.Meta = models.GenerationMeta{
+		SchemaVersion: models.SchemaVersion,
+		GeneratedAt:   data.Date,
+		Generator:     "daily_report",
+		Timezone:      "UTC",
+	}
+	return data
+}
+
+func TestEncodeDecode_RoundTrip(t *testing.T) {
+	for _, format := range []string{FormatJSON, FormatYAML} {
+		t.Run(format, func(t *testing.T) {
+			data := testExportData()
+
+			var raw []byte
+			var err error
+			if format == FormatJSON {
+				raw, err = EncodeJSON(data)
+			} else {
+				raw, err = EncodeYAML(data)
+			}
+			if err != nil {
+				t.Fatalf("encode failed: %v", err)
+			}
+
+			decoded, err := DecodeReportData(raw)
+			if err != nil {
+				t.Fatalf("DecodeReportData failed: %v", err)
+			}
+
+			if !decoded.EndTime.Equal(data.EndTime) {
+				t.Errorf("EndTime mismatch: %v vs %v", decoded.EndTime, data.EndTime)
+			}
+			if len(decoded.Items) != 3 || decoded.Items[0].Metadata["repo"] != "api" {
+				t.Errorf("Items not preserved: %+v", decoded.Items)
+			}
+			if decoded.Stats["git"] != 3 || decoded.Meta.Generator != "daily_report" {
+				t.Errorf("Stats or meta not preserved: %+v %+v", decoded.Stats, decoded.Meta)
+			}
+		})
+	}
+}
+
+func TestEncodeYAML_UsesJSONFieldNames(t *testing.T) {
+	raw, err := EncodeYAML(testExportData())
+	if err != nil {
+		t.Fatalf("EncodeYAML failed: %v", err)
+	}
+
+	for _, key := range []string{"start_time:", "source_status:", "schema_version:"} {
+		if !strings.Contains(string(raw), key) {
+			t.Errorf("Expected YAML to contain %q", key)
+		}
+	}
+}
+
+func TestDecodeReportData_RebuildsDerivedFields(t *testing.T) {
+	raw := `{"date":"2026-10-18T00:00:00Z","start_time":"2026-10-18T00:00:00Z","end_time":"2026-10-18T23:59:59Z",
+"items":[{"type":"git","title":"a","time":"2026-10-18T09:00:00Z","link":""},{"type":"jira","title":"b","time":"2026-10-18T10:00:00Z","link":""}],
+"meta":{"schema_version":"1","generated_at":"2026-10-18T18:00:00Z","generator":"other-tool"}}`
+
+	data, err := DecodeReportData([]byte(raw))
+	if err != nil {
+		t.Fatalf("DecodeReportData failed: %v", err)
+	}
+
+	if len(data.ItemsByType["jira"]) != 1 || data.Stats["git"] != 1 {
+		t.Errorf("Expected derived fields to be rebuilt, got %+v %+v", data.ItemsByType, data.Stats)
+	}
+}
+
+func TestDecodeReportData_SchemaVersion(t *testing.T) {
+	for _, raw := range []string{`{"items":[]}`, `{"items":[],"meta":{"schema_version":"99"}}`} {
+		if _, err := DecodeReportData([]byte(raw)); err == nil {
+			t.Errorf("Expected schema version error for %s", raw)
+		}
+	}
+}
+
+func TestGenerate_FromDecodedData(t *testing.T) {
+	raw, _ := EncodeJSON(testExportData())
+	data, err := DecodeReportData(raw)
+	if err != nil {
+		t.Fatalf("DecodeReportData failed: %v", err)
+	}
+
+	result, err := NewGenerator().Generate(data)
+	if err != nil {
+		t.Fatalf("Generate failed: %v", err)
+	}
+	if !strings.Contains(result, "- Git 提交: 3 次") {
+		t.Errorf("Expected re-rendered report, got:\n%s", result)
+	}
+}
+
+func TestReportDataSchema_CoversFields(t *testing.T) {
+	var schema struct {
+		Properties map[string]json.RawMessage `json:"properties"`
+	}
+	if err := json.Unmarshal(models.ReportDataSchema, &schema); err != nil {
+		t.Fatalf("schema is not valid JSON: %v", err)
+	}
+
+	raw, _ := json.Marshal(testExportData())
+	var fields map[string]json.RawMessage
+	json.Unmarshal(raw, &fields)
+
+	for field := range fields {
+		if _, ok := schema.Properties[field]; !ok {
+			t.Errorf("JSON field %q is missing from the published schema", field)
+		}
+	}
+}
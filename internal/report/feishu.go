@@ -0,0 +1,137 @@
+package report
+
+import (
+	"fmt"
+	"strings"
+
+	"daily_report/pkg/models"
+)
+
+// FormatFeishu renders Feishu interactive card messages
+const FormatFeishu = "feishu"
+
+// feishuMaxBytes is the Feishu bot request body limit for card messages
+const feishuMaxBytes = 30 * 1024
+
+// feishuElement is a Feishu card component
+type feishuElement map[string]interface{}
+
+// FeishuCards renders the report as one or more Feishu bot interactive card messages.
+// Each returned payload is a complete webhook request body within the size limit.
+func (g *Generator) FeishuCards(data *models.ReportData, opts CardOptions) ([][]byte, error) {
+	maxBytes := opts.MaxBytes
+	if maxBytes <= 0 {
+		maxBytes = feishuMaxBytes
+	}
+
+	all, sections := g.cardSections(data)
+	title := g.cardTitle(data)
+
+	// Footer elements are reserved on every page while packing and added to the last page only
+	footer := []feishuElement{
+		{"tag": "hr"},
+		{"tag": "markdown", "content": sourceStatusLine(data.SourceStatus), "text_size": "notation"},
+	}
+	if opts.ReportURL != "" {
+		footer = append(footer, feishuElement{
+			"tag":       "button",
+			"text":      map[string]string{"tag": "plain_text", "content": "查看完整日报"},
+			"type":      "primary",
+			"behaviors": []map[string]string{{"type": "open_url", "default_url": opts.ReportURL}},
+		})
+	}
+
+	var panels []feishuElement
+	for _, s := range sections {
+		chunks := chunkLines(g.cardSectionLines(s, markdownMarkup), maxBytes/2)
+		for i, chunk := range chunks {
+			heading := fmt.Sprintf("**%s** (%d)", s.Heading, s.Count)
+			if i > 0 {
+				heading = fmt.Sprintf("**%s** (续)", s.Heading)
+			}
+			panels = append(panels, feishuElement{
+				"tag":      "collapsible_panel",
+				"expanded": false,
+				"header": map[string]interface{}{
+					"title": map[string]string{"tag": "markdown", "content": heading},
+				},
+				"elements": []feishuElement{{"tag": "markdown", "content": strings.Join(chunk, "\n")}},
+			})
+		}
+	}
+
+	pages := [][]feishuElement{{feishuStats(all)}}
+	for _, panel := range panels {
+		current := pages[len(pages)-1]
+		candidate := append(append([]feishuElement{}, current...), panel)
+		size, err := feishuSize(title+" (99/99)", append(candidate, footer...))
+		if err != nil {
+			return nil, err
+		}
+		if size > maxBytes && len(current) > 0 {
+			pages = append(pages, []feishuElement{panel})
+			continue
+		}
+		pages[len(pages)-1] = candidate
+	}
+	pages[len(pages)-1] = append(pages[len(pages)-1], footer...)
+
+	messages := make([][]byte, 0, len(pages))
+	for i, elements := range pages {
+		msg, err := marshalPayload(feishuMessage(pagedTitle(title, i+1, len(pages)), elements))
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode Feishu card: %w", err)
+		}
+		messages = append(messages, msg)
+	}
+
+	return messages, nil
+}
+
+// feishuStats renders one column per section with its count
+func feishuStats(sections []Section) feishuElement {
+	columns := make([]feishuElement, 0, len(sections))
+	for _, s := range sections {
+		columns = append(columns, feishuElement{
+			"tag":    "column",
+			"width":  "weighted",
+			"weight": 1,
+			"elements": []feishuElement{{
+				"tag":        "markdown",
+				"content":    fmt.Sprintf("**%d**\n%s", s.Count, s.Label),
+				"text_align": "center",
+			}},
+		})
+	}
+
+	return feishuElement{
+		"tag":       "column_set",
+		"flex_mode": "bisect",
+		"columns":   columns,
+	}
+}
+
+// feishuMessage wraps card elements into a bot webhook payload
+func feishuMessage(title string, elements []feishuElement) map[string]interface{} {
+	return map[string]interface{}{
+		"msg_type": "interactive",
+		"card": map[string]interface{}{
+			"schema": "2.0",
+			"config": map[string]interface{}{"wide_screen_mode": true},
+			"header": map[string]interface{}{
+				"title":    map[string]string{"tag": "plain_text", "content": title},
+				"template": "blue",
+			},
+			"body": map[string]interface{}{"elements": elements},
+		},
+	}
+}
+
+// feishuSize returns the encoded size of a message with the given elements
+func feishuSize(title string, elements []feishuElement) (int, error) {
+	msg, err := marshalPayload(feishuMessage(title, elements))
+	if err != nil {
+		return 0, fmt.Errorf("failed to encode Feishu card: %w", err)
+	}
+	return len(msg), nil
+}
@@ -0,0 +1,151 @@
+package report
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"text/template"
+	"unicode/utf8"
+
+	"daily_report/pkg/models"
+)
+
+// templateFuncs returns the function library available to Go templates
+func (g *Generator) templateFuncs(data *models.ReportData, itemsByType map[string][]models.Item) template.FuncMap {
+	return template.FuncMap{
+		"formatDate": g.formatDate,
+		"clock":      g.clock,
+		"now":        g.now,
+		"meta":       meta,
+		"groupBy":    groupBy,
+		"sortBy":     sortBy,
+		"truncate":   truncate,
+		"plural":     plural,
+		"mdEscape":   mdEscape,
+		"join":       strings.Join,
+		"lower":      strings.ToLower,
+		"upper":      strings.ToUpper,
+		"sourceStatus": func() string {
+			return g.buildSourceStatus(data.SourceStatus)
+		},
+		"section": func(typ string) string {
+			return g.renderSection(typ, itemsByType[typ])
+		},
+		"sections": func() []Section {
+			return g.buildSections(itemsByType)
+		},
+		"days": func() []Day {
+			return g.buildDays(data)
+		},
+		"multiDay": func() bool {
+			return g.isMultiDay(data)
+		},
+		"weekday": weekday,
+	}
+}
+
+// renderSection renders the built-in Markdown for an item type, or a generic section for other types
+func (g *Generator) renderSection(typ string, items []models.Item) string {
+	switch typ {
+	case "git":
+		return g.renderGitItems(items)
+	case "meeting":
+		return g.renderMeetingItems(items)
+	case "jira":
+		return g.renderJiraItems(items)
+	case "confluence":
+		return g.renderConfluenceItems(items)
+	default:
+		return g.renderGenericItems(typ, items)
+	}
+}
+
+// meta returns an item's metadata value as a string
+func meta(key string, item models.Item) string {
+	v, ok := item.Metadata[key]
+	if !ok || v == nil {
+		return ""
+	}
+	return fmt.Sprint(v)
+}
+
+// groupBy groups items by a metadata key; ranging over the result visits keys in sorted order
+func groupBy(key string, items []models.Item) map[string][]models.Item {
+	result := make(map[string][]models.Item)
+	for _, item := range items {
+		k := meta(key, item)
+		result[k] = append(result[k], item)
+	}
+	return result
+}
+
+// sortBy returns a sorted copy of items.
+// Supported fields are time, title, type or any metadata key; a leading "-" reverses the order.
+func sortBy(field string, items []models.Item) []models.Item {
+	desc := strings.HasPrefix(field, "-")
+	field = strings.TrimPrefix(field, "-")
+
+	sorted := make([]models.Item, len(items))
+	copy(sorted, items)
+
+	less := func(a, b models.Item) bool {
+		switch field {
+		case "time":
+			return a.Time.Before(b.Time)
+		case "title":
+			return a.Title < b.Title
+		case "type":
+			return a.Type < b.Type
+		default:
+			return meta(field, a) < meta(field, b)
+		}
+	}
+
+	sort.SliceStable(sorted, func(i, j int) bool {
+		if desc {
+			return less(sorted[j], sorted[i])
+		}
+		return less(sorted[i], sorted[j])
+	})
+
+	return sorted
+}
+
+// truncate shortens s to at most n runes, appending an ellipsis when cut
+func truncate(n int, s string) string {
+	if n <= 0 || utf8.RuneCountInString(s) <= n {
+		return s
+	}
+	runes := []rune(s)
+	if n == 1 {
+		return "…"
+	}
+	return string(runes[:n-1]) + "…"
+}
+
+// plural returns singular when count is 1 and plural otherwise
+func plural(count int, singular, plural string) string {
+	if count == 1 {
+		return singular
+	}
+	return plural
+}
+
+// markdownEscaper escapes characters with special meaning in Markdown
+var markdownEscaper = strings.NewReplacer(
+	`\`, `\\`,
+	"`", "\\`",
+	"*", `\*`,
+	"_", `\_`,
+	"[", `\[`,
+	"]", `\]`,
+	"<", `\<`,
+	">", `\>`,
+	"#", `\#`,
+	"|", `\|`,
+)
+
+// mdEscape escapes s for literal use in Markdown
+func mdEscape(s string) string {
+	return markdownEscaper.Replace(s)
+}
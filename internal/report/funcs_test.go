@@ -0,0 +1,63 @@
+package report
+
+import (
+	"testing"
+	"time"
+
+	"daily_report/pkg/models"
+)
+
+func TestTruncate(t *testing.T) {
+	tests := []struct {
+		n        int
+		input    string
+		expected string
+	}{
+		{10, "short", "short"},
+		{5, "exactly", "exac…"},
+		{3, "修复登录超时", "修复…"},
+		{0, "unlimited", "unlimited"},
+	}
+
+	for _, tt := range tests {
+		if got := truncate(tt.n, tt.input); got != tt.expected {
+			t.Errorf("truncate(%d, %q) = %q, want %q", tt.n, tt.input, got, tt.expected)
+		}
+	}
+}
+
+func TestSortBy(t *testing.T) {
+	base := time.Date(2026, 10, 18, 9, 0, 0, 0, time.UTC)
+	items := []models.Item{
+		{Title: "b", Time: base.Add(time.Hour), Metadata: map[string]interface{}{"repo": "web"}},
+		{Title: "a", Time: base, Metadata: map[string]interface{}{"repo": "api"}},
+	}
+
+	if got := sortBy("time", items); got[0].Title != "a" {
+		t.Error("Expected ascending time order")
+	}
+	if got := sortBy("-title", items); got[0].Title != "b" {
+		t.Error("Expected descending title order")
+	}
+	if got := sortBy("repo", items); got[0].Title != "a" {
+		t.Error("Expected metadata key order")
+	}
+	if items[0].Title != "b" {
+		t.Error("sortBy must not modify its input")
+	}
+}
+
+func TestMdEscape(t *testing.T) {
+	if got := mdEscape("fix *bold* [link] #1"); got != `fix \*bold\* \[link\] \#1` {
+		t.Errorf("Unexpected escape result: %s", got)
+	}
+}
+
+func TestPlural(t *testing.T) {
+	if plural(1, "commit", "commits") != "commit" {
+		t.Error("Expected singular for 1")
+	}
+	if plural(0, "commit", "commits") != "commits" {
+		t.Error("Expected plural for 0")
+	}
+}
@@ -0,0 +1,122 @@
+package report
+
+import (
+	"bytes"
+	_ "embed"
+	"fmt"
+	"html/template"
+	"sort"
+	"strings"
+	"time"
+
+	"daily_report/pkg/models"
+)
+
+// FormatHTML renders a standalone HTML page
+const FormatHTML = "html"
+
+// htmlTemplate is the built-in standalone HTML report template
+//
+//go:embed html/report.html.tmpl
+var htmlTemplate string
+
+// htmlView is the data passed to the HTML template
+type htmlView struct {
+	Date         time.Time
+	GeneratedAt  time.Time
+	Sections     []htmlSection
+	SourceStatus []models.SourceStatus
+}
+
+// htmlSection is a section whose items are split into collapsible groups
+type htmlSection struct {
+	Section
+	Groups []htmlGroup
+}
+
+// htmlGroup is a named group of items; an empty name renders a plain list
+type htmlGroup struct {
+	Name  string
+	Items []models.Item
+}
+
+// GenerateHTML generates a self-contained HTML report with inline styling
+func (g *Generator) GenerateHTML(data *models.ReportData) (string, error) {
+	return g.renderHTML("report.html", htmlTemplate, data)
+}
+
+// renderHTML executes an html/template over the sectioned report view
+func (g *Generator) renderHTML(name, text string, data *models.ReportData) (string, error) {
+	t, err := template.New(name).Funcs(template.FuncMap{
+		"formatDate": g.formatDate,
+		"clock":      g.clock,
+		"meta":       meta,
+		"shortHash":  shortHash,
+		"isURL":      isURL,
+		"title": func(dayLayout, monthLayout string) string {
+			return g.reportTitle(data, dayLayout, monthLayout)
+		},
+	}).Parse(text)
+	if err != nil {
+		return "", fmt.Errorf("failed to parse %s template: %w", name, err)
+	}
+
+	itemsByType := g.groupItemsByType(data.Items)
+
+	view := htmlView{
+		Date:        data.Date,
+		GeneratedAt: g.now(),
+	}
+	for _, s := range g.buildSections(itemsByType) {
+		view.Sections = append(view.Sections, htmlSection{Section: s, Groups: htmlGroups(s)})
+	}
+
+	names := make([]string, 0, len(data.SourceStatus))
+	for name := range data.SourceStatus {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		view.SourceStatus = append(view.SourceStatus, data.SourceStatus[name])
+	}
+
+	var buf bytes.Buffer
+	if err := t.Execute(&buf, view); err != nil {
+		return "", fmt.Errorf("failed to render %s: %w", name, err)
+	}
+
+	return buf.String(), nil
+}
+
+// htmlGroups groups git commits by repository; other sections form a single unnamed group
+func htmlGroups(s Section) []htmlGroup {
+	if s.Type != "git" {
+		return []htmlGroup{{Items: s.Items}}
+	}
+
+	byRepo := groupBy("repo", s.Items)
+	repos := make([]string, 0, len(byRepo))
+	for repo := range byRepo {
+		repos = append(repos, repo)
+	}
+	sort.Strings(repos)
+
+	groups := make([]htmlGroup, 0, len(repos))
+	for _, repo := range repos {
+		groups = append(groups, htmlGroup{Name: repo, Items: byRepo[repo]})
+	}
+	return groups
+}
+
+// shortHash abbreviates a commit hash to 7 characters
+func shortHash(hash string) string {
+	if len(hash) > 7 {
+		return hash[:7]
+	}
+	return hash
+}
+
+// isURL reports whether link can be rendered as a clickable web link
+func isURL(link string) bool {
+	return strings.HasPrefix(link, "http://") || strings.HasPrefix(link, "https://")
+}
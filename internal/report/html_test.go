@@ -0,0 +1,58 @@
+package report
+
+import (
+	"strings"
+	"testing"
+
+	"daily_report/pkg/models"
+)
+
+func TestGenerateHTML(t *testing.T) {
+	data := testGoTemplateData()
+	data.Items = append(data.Items,
+		models.Item{Type: "jira", Title: "PROJ-1 <script>alert(1)</script>", Time: data.Date, Link: "https://jira.example.com/browse/PROJ-1",
+			Metadata: map[string]interface{}{"status": "Done"}},
+		models.Item{Type: "meeting", Title: "Standup", Time: data.Date, Link: "javascript:alert(1)"},
+	)
+
+	result, err := NewGenerator().GenerateHTML(data)
+	if err != nil {
+		t.Fatalf("GenerateHTML failed: %v", err)
+	}
+
+	for _, want := range []string{
+		"<!DOCTYPE html>",
+		"<style>",
+		"@media print",
+		"<h2>💻 代码提交</h2>",
+		"<summary>api <span class=\"meta\">(2)</span></summary>",
+		"<summary>web <span class=\"meta\">(1)</span></summary>",
+		`<a href="https://jira.example.com/browse/PROJ-1">PROJ-1 &lt;script&gt;alert(1)&lt;/script&gt;</a>`,
+		`<span class="hash">abc1234</span>`,
+		"✅ git",
+	} {
+		if !strings.Contains(result, want) {
+			t.Errorf("Expected HTML to contain %q", want)
+		}
+	}
+
+	if strings.Contains(result, "<script>") {
+		t.Error("Item titles must be escaped")
+	}
+	if strings.Contains(result, `href="javascript:`) {
+		t.Error("Non-web links must not become clickable")
+	}
+	if strings.Contains(result, "<link") || strings.Contains(result, "src=") {
+		t.Error("HTML report must not reference external assets")
+	}
+}
+
+func TestGenerateHTML_EmptySections(t *testing.T) {
+	result, err := NewGenerator().GenerateHTML(&models.ReportData{Date: testGoTemplateData().Date})
+	if err != nil {
+		t.Fatalf("GenerateHTML failed: %v", err)
+	}
+	if strings.Contains(result, "<section") {
+		t.Error("Empty sections should not be rendered")
+	}
+}
@@ -0,0 +1,196 @@
+package report
+
+import (
+	"embed"
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+//go:embed templates
+var builtinFS embed.FS
+
+// templateExtensions are tried in order when resolving a template by name
+var templateExtensions = []string{".md.tmpl", ".tmpl", ".md"}
+
+// extendsPattern matches a leading {{/* extends "name" */}} directive
+var extendsPattern = regexp.MustCompile(`^\s*\{\{-?\s*/\*\s*extends\s+"([^"]+)"\s*\*/\s*-?\}\}`)
+
+// Template is a loaded report template together with its partials
+type Template struct {
+	Name string
+	// Main is the entry point source
+	Main string
+	// Partials are parsed after Main in order, so later definitions override earlier ones
+	Partials []Partial
+}
+
+// Partial is a named template file that can be invoked or overridden with define/block
+type Partial struct {
+	Name   string
+	Source string
+}
+
+// TemplateLoader resolves templates by path or by name through a search path
+type TemplateLoader struct {
+	dirs []string
+}
+
+// NewTemplateLoader creates a loader searching dirs in order before the built-in templates
+func NewTemplateLoader(dirs ...string) *TemplateLoader {
+	return &TemplateLoader{dirs: dirs}
+}
+
+// DefaultSearchPath returns the project and user template directories
+func DefaultSearchPath() []string {
+	dirs := []string{"templates"}
+	if home, err := os.UserHomeDir(); err == nil {
+		dirs = append(dirs, filepath.Join(home, ".config", "daily_report", "templates"))
+	}
+	return dirs
+}
+
+// Load resolves ref, which is either a file or directory path or a template name
+func (l *TemplateLoader) Load(ref string) (*Template, error) {
+	return l.load(ref, map[string]bool{})
+}
+
+// load resolves ref, tracking visited templates to reject inheritance cycles
+func (l *TemplateLoader) load(ref string, visiting map[string]bool) (*Template, error) {
+	if visiting[ref] {
+		return nil, fmt.Errorf("template inheritance cycle at %q", ref)
+	}
+	visiting[ref] = true
+
+	tpl, err := l.resolve(ref)
+	if err != nil {
+		return nil, err
+	}
+
+	// Apply inheritance: the child is parsed on top of its parent
+	m := extendsPattern.FindStringSubmatch(tpl.Main)
+	if m == nil {
+		return tpl, nil
+	}
+
+	parent, err := l.load(m[1], visiting)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load parent of template %s: %w", tpl.Name, err)
+	}
+
+	return &Template{
+		Name: tpl.Name,
+		Main: parent.Main,
+		Partials: append(append(parent.Partials, Partial{
+			Name: tpl.Name + ".main",
+			// Keep line numbers of the child aligned with its file
+			Source: strings.Repeat("\n", strings.Count(m[0], "\n")) + tpl.Main[len(m[0]):],
+		}), tpl.Partials...),
+	}, nil
+}
+
+// resolve finds ref as a path or by name without applying inheritance
+func (l *TemplateLoader) resolve(ref string) (*Template, error) {
+	if info, err := os.Stat(ref); err == nil {
+		if info.IsDir() {
+			return loadTemplateDir(os.DirFS(ref), ".", templateStem(filepath.Base(ref)))
+		}
+		data, err := os.ReadFile(ref)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read template %s: %w", ref, err)
+		}
+		return &Template{Name: templateStem(filepath.Base(ref)), Main: string(data)}, nil
+	}
+
+	if strings.ContainsAny(ref, `/\`) {
+		return nil, fmt.Errorf("template not found: %s", ref)
+	}
+
+	for _, fsys := range l.searchFS() {
+		tpl, err := loadNamedTemplate(fsys, ref)
+		if err != nil {
+			return nil, err
+		}
+		if tpl != nil {
+			return tpl, nil
+		}
+	}
+
+	return nil, fmt.Errorf("template %q not found in search path %s or built-in templates", ref, strings.Join(l.dirs, ", "))
+}
+
+// searchFS returns the file systems to search in priority order
+func (l *TemplateLoader) searchFS() []fs.FS {
+	result := make([]fs.FS, 0, len(l.dirs)+1)
+	for _, dir := range l.dirs {
+		result = append(result, os.DirFS(dir))
+	}
+	builtin, _ := fs.Sub(builtinFS, "templates")
+	return append(result, builtin)
+}
+
+// loadNamedTemplate looks up name as a directory or file in fsys; it returns nil if absent
+func loadNamedTemplate(fsys fs.FS, name string) (*Template, error) {
+	if info, err := fs.Stat(fsys, name); err == nil && info.IsDir() {
+		return loadTemplateDir(fsys, name, name)
+	}
+
+	for _, ext := range templateExtensions {
+		data, err := fs.ReadFile(fsys, name+ext)
+		if err == nil {
+			return &Template{Name: name, Main: string(data)}, nil
+		}
+		if !os.IsNotExist(err) {
+			return nil, fmt.Errorf("failed to read template %s: %w", name+ext, err)
+		}
+	}
+
+	return nil, nil
+}
+
+// loadTemplateDir loads a template directory: the main file is the entry point and
+// every other file becomes a partial named after its file stem
+func loadTemplateDir(fsys fs.FS, dir, name string) (*Template, error) {
+	entries, err := fs.ReadDir(fsys, dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read template directory %s: %w", name, err)
+	}
+
+	tpl := &Template{Name: name}
+	foundMain := false
+	for _, e := range entries {
+		if e.IsDir() {
+			continue
+		}
+		data, err := fs.ReadFile(fsys, path.Join(dir, e.Name()))
+		if err != nil {
+			return nil, fmt.Errorf("failed to read template %s: %w", e.Name(), err)
+		}
+
+		stem := templateStem(e.Name())
+		if stem == "main" {
+			tpl.Main = string(data)
+			foundMain = true
+			continue
+		}
+		tpl.Partials = append(tpl.Partials, Partial{Name: stem, Source: string(data)})
+	}
+
+	if !foundMain {
+		return nil, fmt.Errorf("template directory %s has no main file", name)
+	}
+
+	return tpl, nil
+}
+
+// templateStem strips all extensions from a file name
+func templateStem(name string) string {
+	if idx := strings.Index(name, "."); idx > 0 {
+		return name[:idx]
+	}
+	return name
+}
@@ -0,0 +1,185 @@
+package report
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"daily_report/pkg/models"
+)
+
+func writeTemplateFiles(t *testing.T, dir string, files map[string]string) {
+	t.Helper()
+	for name, content := range files {
+		path := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func TestTemplateLoader_LoadByPath(t *testing.T) {
+	dir := t.TempDir()
+	writeTemplateFiles(t, dir, map[string]string{"custom.md": "# {{date}}"})
+
+	tpl, err := NewTemplateLoader().Load(filepath.Join(dir, "custom.md"))
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+
+	if tpl.Main != "# {{date}}" || tpl.Name != "custom" {
+		t.Errorf("Unexpected template: %+v", tpl)
+	}
+}
+
+func TestTemplateLoader_SearchPathOrder(t *testing.T) {
+	project := t.TempDir()
+	user := t.TempDir()
+	writeTemplateFiles(t, project, map[string]string{"weekly.md": "project"})
+	writeTemplateFiles(t, user, map[string]string{"weekly.md.tmpl": "user", "monthly.tmpl": "user monthly"})
+
+	loader := NewTemplateLoader(project, user)
+
+	tpl, err := loader.Load("weekly")
+	if err != nil || tpl.Main != "project" {
+		t.Errorf("Expected project template to win, got %+v, err=%v", tpl, err)
+	}
+
+	tpl, err = loader.Load("monthly")
+	if err != nil || tpl.Main != "user monthly" {
+		t.Errorf("Expected user template, got %+v, err=%v", tpl, err)
+	}
+
+	if _, err := loader.Load("missing"); err == nil {
+		t.Error("Expected error for unknown template name")
+	}
+}
+
+func TestTemplateLoader_Builtin(t *testing.T) {
+	tpl, err := NewTemplateLoader(t.TempDir()).Load("default")
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+
+	if !strings.Contains(tpl.Main, `{{block "summary" .}}`) {
+		t.Error("Expected built-in default template to declare a summary block")
+	}
+}
+
+func TestBuiltinWeeklyTemplate(t *testing.T) {
+	data := testGoTemplateData()
+	data.Period = models.PeriodWeek
+	data.StartTime = time.Date(2026, 10, 12, 0, 0, 0, 0, time.UTC)
+	data.EndTime = time.Date(2026, 10, 18, 23, 59, 59, 0, time.UTC)
+	data.Aggregate = &models.Aggregate{
+		Days: []models.DayStats{
+			{Date: data.StartTime, Stats: map[string]int{"git": 2}, Total: 2},
+			{Date: data.StartTime.AddDate(0, 0, 1), Stats: map[string]int{}, Total: 0},
+		},
+		Repos:  []models.RepoStats{{Repo: "api", Commits: 2, ActiveDays: 1}},
+		Themes: []models.ThemeStats{{Theme: "auth", Count: 2}},
+	}
+
+	gen := NewGeneratorWithTemplatePath("weekly")
+	gen.SetLoader(NewTemplateLoader(t.TempDir()))
+	result, err := gen.Generate(data)
+	if err != nil {
+		t.Fatalf("Generate failed: %v", err)
+	}
+
+	for _, want := range []string{
+		"# 周报 - 2026年10月12日 至 2026年10月18日",
+		"- auth (2)",
+		"| 10-12 Mon | 2 | 2 | 0 | 0 | 0 |",
+		"| 10-13 Tue | 0 |",
+		"| api | 2 | 1 |",
+		"## 💻 代码提交",
+	} {
+		if !strings.Contains(result, want) {
+			t.Errorf("Expected weekly report to contain %q\n%s", want, result)
+		}
+	}
+	if len(gen.Warnings()) > 0 {
+		t.Errorf("Expected no warnings, got %v", gen.Warnings())
+	}
+}
+
+func TestTemplateLoader_Directory(t *testing.T) {
+	dir := t.TempDir()
+	writeTemplateFiles(t, dir, map[string]string{
+		"team/main.md":     `{{template "title" .}}|{{block "git" .}}base git{{end}}`,
+		"team/title.md":    "TITLE",
+		"team/git.md.tmpl": "team git",
+	})
+
+	tpl, err := NewTemplateLoader(dir).Load("team")
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+
+	gen := &Generator{}
+	result, err := gen.renderGoTemplate(testGoTemplateData(), nil, nil, tpl)
+	if err != nil {
+		t.Fatalf("render failed: %v", err)
+	}
+	if result != "TITLE|team git" {
+		t.Errorf("Expected partials to be applied, got '%s'", result)
+	}
+}
+
+func TestTemplateLoader_Extends(t *testing.T) {
+	dir := t.TempDir()
+	writeTemplateFiles(t, dir, map[string]string{
+		"base.md": `[{{block "git" .}}base{{end}}][{{block "footer" .}}footer{{end}}]`,
+		"me.md":   "{{/* extends \"base\" */}}\n{{define \"git\"}}mine{{end}}",
+	})
+
+	gen := NewGeneratorWithTemplatePath("me")
+	gen.SetLoader(NewTemplateLoader(dir))
+
+	result, err := gen.Generate(testGoTemplateData())
+	if err != nil {
+		t.Fatalf("Generate failed: %v", err)
+	}
+	if result != "[mine][footer]" {
+		t.Errorf("Expected only git block overridden, got '%s'", result)
+	}
+}
+
+func TestTemplateLoader_ExtendsBuiltinDefault(t *testing.T) {
+	dir := t.TempDir()
+	writeTemplateFiles(t, dir, map[string]string{
+		"me.md": "{{/* extends \"default\" */}}{{define \"git\"}}## 我的提交\n{{end}}",
+	})
+
+	gen := NewGeneratorWithTemplatePath("me")
+	gen.SetLoader(NewTemplateLoader(dir))
+
+	result, err := gen.Generate(testGoTemplateData())
+	if err != nil {
+		t.Fatalf("Generate failed: %v", err)
+	}
+	if !strings.Contains(result, "## 我的提交") || !strings.Contains(result, "## 📊 汇总统计") {
+		t.Errorf("Expected overridden git block inside default layout, got:\n%s", result)
+	}
+	if strings.Contains(result, "## 💻 代码提交") {
+		t.Error("Default git section should have been replaced")
+	}
+}
+
+func TestTemplateLoader_ExtendsCycle(t *testing.T) {
+	dir := t.TempDir()
+	writeTemplateFiles(t, dir, map[string]string{
+		"a.md": `{{/* extends "b" */}}`,
+		"b.md": `{{/* extends "a" */}}`,
+	})
+
+	if _, err := NewTemplateLoader(dir).Load("a"); err == nil {
+		t.Error("Expected error for inheritance cycle")
+	}
+}
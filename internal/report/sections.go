@@ -0,0 +1,109 @@
+package report
+
+import (
+	"sort"
+
+	"daily_report/internal/config"
+	"daily_report/pkg/models"
+)
+
+// Section is the rendering view of one item type
+type Section struct {
+	Type    string
+	Heading string
+	Label   string
+	Unit    string
+	Count   int
+	Items   []models.Item
+}
+
+// builtinSections lists the default order and wording of the built-in item types
+var builtinSections = []config.SectionConfig{
+	{Type: "git", Heading: "💻 代码提交", Label: "Git 提交", Unit: "次"},
+	{Type: "meeting", Heading: "📅 会议", Label: "会议", Unit: "场"},
+	{Type: "jira", Heading: "🎯 Jira 任务", Label: "Jira 任务", Unit: "个"},
+	{Type: "confluence", Heading: "📝 Confluence 文档", Label: "Confluence 文档", Unit: "篇"},
+}
+
+// SetSections configures section order, visibility and wording
+func (g *Generator) SetSections(sections []config.SectionConfig) {
+	g.sections = sections
+}
+
+// sectionConfig returns the effective configuration of an item type
+func (g *Generator) sectionConfig(typ string) config.SectionConfig {
+	result := config.SectionConfig{Type: typ, Heading: typ, Label: typ}
+	for _, s := range builtinSections {
+		if s.Type == typ {
+			result = s
+			break
+		}
+	}
+
+	for _, s := range g.sections {
+		if s.Type != typ {
+			continue
+		}
+		if s.Heading != "" {
+			result.Heading = s.Heading
+		}
+		if s.Label != "" {
+			result.Label = s.Label
+		}
+		if s.Unit != "" {
+			result.Unit = s.Unit
+		}
+		result.Hidden = s.Hidden
+	}
+
+	return result
+}
+
+// buildSections returns the visible sections in display order: configured sections first,
+// then the remaining built-in types, then any other collected types alphabetically
+func (g *Generator) buildSections(itemsByType map[string][]models.Item) []Section {
+	var order []string
+	seen := make(map[string]bool)
+	add := func(typ string) {
+		if !seen[typ] {
+			seen[typ] = true
+			order = append(order, typ)
+		}
+	}
+
+	for _, s := range g.sections {
+		add(s.Type)
+	}
+	for _, s := range builtinSections {
+		add(s.Type)
+	}
+
+	var extra []string
+	for typ := range itemsByType {
+		if !seen[typ] {
+			extra = append(extra, typ)
+		}
+	}
+	sort.Strings(extra)
+	for _, typ := range extra {
+		add(typ)
+	}
+
+	sections := make([]Section, 0, len(order))
+	for _, typ := range order {
+		cfg := g.sectionConfig(typ)
+		if cfg.Hidden {
+			continue
+		}
+		sections = append(sections, Section{
+			Type:    typ,
+			Heading: cfg.Heading,
+			Label:   cfg.Label,
+			Unit:    cfg.Unit,
+			Count:   len(itemsByType[typ]),
+			Items:   itemsByType[typ],
+		})
+	}
+
+	return sections
+}
@@ -0,0 +1,96 @@
+package report
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"daily_report/internal/config"
+	"daily_report/pkg/models"
+)
+
+func sectionTypes(sections []Section) []string {
+	types := make([]string, 0, len(sections))
+	for _, s := range sections {
+		types = append(types, s.Type)
+	}
+	return types
+}
+
+func TestBuildSections_DefaultOrder(t *testing.T) {
+	gen := NewGenerator()
+
+	sections := gen.buildSections(map[string][]models.Item{
+		"zentao": {{}},
+		"git":    {{}, {}},
+		"feishu": {{}},
+	})
+
+	got := strings.Join(sectionTypes(sections), ",")
+	if got != "git,meeting,jira,confluence,feishu,zentao" {
+		t.Errorf("Unexpected section order: %s", got)
+	}
+	if sections[0].Count != 2 || sections[0].Heading != "💻 代码提交" {
+		t.Errorf("Unexpected git section: %+v", sections[0])
+	}
+}
+
+func TestBuildSections_Configured(t *testing.T) {
+	gen := NewGenerator()
+	gen.SetSections([]config.SectionConfig{
+		{Type: "jira", Heading: "Tasks"},
+		{Type: "meeting", Hidden: true},
+		{Type: "git"},
+	})
+
+	sections := gen.buildSections(map[string][]models.Item{"git": {{}}})
+
+	got := strings.Join(sectionTypes(sections), ",")
+	if got != "jira,git,confluence" {
+		t.Errorf("Unexpected section order: %s", got)
+	}
+	if sections[0].Heading != "Tasks" || sections[0].Label != "Jira 任务" {
+		t.Errorf("Expected heading override with default label, got %+v", sections[0])
+	}
+}
+
+func TestGenerate_DefaultTemplate(t *testing.T) {
+	data := testGoTemplateData()
+	data.Items = append(data.Items, models.Item{Type: "zentao", Title: "Bug #12", Time: data.Date, Link: "https://zentao/bug/12"})
+
+	gen := NewGenerator()
+	gen.SetSections([]config.SectionConfig{{Type: "confluence", Hidden: true}})
+
+	result, err := gen.Generate(data)
+	if err != nil {
+		t.Fatalf("Generate failed: %v", err)
+	}
+
+	for _, want := range []string{"# 日报 - 2026年10月18日", "- Git 提交: 3 次", "## 💻 代码提交", "### api", "## zentao", "### Bug #12", "- 链接: https://zentao/bug/12", "数据源状态: ✅ git"} {
+		if !strings.Contains(result, want) {
+			t.Errorf("Expected output to contain %q, got:\n%s", want, result)
+		}
+	}
+	if strings.Contains(result, "Confluence") {
+		t.Error("Hidden section should not be rendered")
+	}
+	if strings.Index(result, "### api") > strings.Index(result, "### web") {
+		t.Error("Repositories should be rendered in sorted order")
+	}
+}
+
+func TestGenerate_SectionOverride(t *testing.T) {
+	tmpl := `{{range sections}}{{if .Items}}{{render .}}{{end}}{{end}}{{define "git"}}[{{.Heading}}: {{len .Items}}]{{end}}`
+	gen := NewGeneratorWithTemplate(tmpl)
+
+	data := testGoTemplateData()
+	data.Items = append(data.Items, models.Item{Type: "jira", Title: "PROJ-1", Time: time.Now()})
+
+	result, err := gen.Generate(data)
+	if err != nil {
+		t.Fatalf("Generate failed: %v", err)
+	}
+	if !strings.HasPrefix(result, "[💻 代码提交: 3]## 🎯 Jira 任务") {
+		t.Errorf("Expected git override followed by built-in jira section, got:\n%s", result)
+	}
+}
@@ -0,0 +1,106 @@
+package report
+
+import (
+	"fmt"
+	"strings"
+
+	"daily_report/pkg/models"
+)
+
+// FormatSlack renders Slack Block Kit messages
+const FormatSlack = "slack"
+
+// Slack Block Kit limits
+const (
+	slackMaxBlocks      = 50
+	slackMaxHeaderChars = 150
+	slackMaxTextChars   = 3000
+)
+
+// slackBlock is a Slack Block Kit block
+type slackBlock map[string]interface{}
+
+// slackMarkup is Slack mrkdwn with <url|title> links and escaped control characters
+var slackMarkup = cardMarkup{
+	bold:   func(text string) string { return "*" + text + "*" },
+	link:   func(title, url string) string { return fmt.Sprintf("<%s|%s>", url, title) },
+	escape: slackEscape,
+}
+
+// SlackMessages renders the report as one or more Slack incoming webhook messages.
+// Messages are split when the block count limit is exceeded.
+func (g *Generator) SlackMessages(data *models.ReportData, opts CardOptions) ([][]byte, error) {
+	all, sections := g.cardSections(data)
+	title := g.cardTitle(data)
+
+	var stats []string
+	for _, s := range all {
+		stats = append(stats, fmt.Sprintf("%s *%d*", slackEscape(s.Label), s.Count))
+	}
+
+	footer := []slackBlock{
+		{"type": "divider"},
+		{"type": "context", "elements": []slackBlock{slackText(sourceStatusLine(data.SourceStatus))}},
+	}
+	if opts.ReportURL != "" {
+		footer = append(footer, slackBlock{
+			"type": "actions",
+			"elements": []slackBlock{{
+				"type": "button",
+				"text": slackPlainText("查看完整日报"),
+				"url":  opts.ReportURL,
+			}},
+		})
+	}
+
+	body := []slackBlock{{"type": "section", "text": slackText(strings.Join(stats, " · "))}}
+	for _, s := range sections {
+		body = append(body, slackBlock{
+			"type": "header",
+			"text": slackPlainText(fmt.Sprintf("%s (%d)", s.Heading, s.Count)),
+		})
+		for _, chunk := range chunkLines(g.cardSectionLines(s, slackMarkup), slackMaxTextChars) {
+			body = append(body, slackBlock{"type": "section", "text": slackText(strings.Join(chunk, "\n"))})
+		}
+	}
+
+	// Each page carries a title header; the last one also carries the footer
+	perPage := slackMaxBlocks - 1 - len(footer)
+	var pages [][]slackBlock
+	for len(body) > perPage {
+		pages = append(pages, body[:perPage])
+		body = body[perPage:]
+	}
+	pages = append(pages, body)
+
+	messages := make([][]byte, 0, len(pages))
+	for i, page := range pages {
+		pageTitle := pagedTitle(title, i+1, len(pages))
+		blocks := append([]slackBlock{{"type": "header", "text": slackPlainText(pageTitle)}}, page...)
+		if i == len(pages)-1 {
+			blocks = append(blocks, footer...)
+		}
+		msg, err := marshalPayload(map[string]interface{}{"text": pageTitle, "blocks": blocks})
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode Slack message: %w", err)
+		}
+		messages = append(messages, msg)
+	}
+
+	return messages, nil
+}
+
+// slackText returns a mrkdwn text object within the block text limit
+func slackText(text string) slackBlock {
+	return slackBlock{"type": "mrkdwn", "text": truncateBytes(text, slackMaxTextChars)}
+}
+
+// slackPlainText returns a plain_text object within the header text limit
+func slackPlainText(text string) slackBlock {
+	return slackBlock{"type": "plain_text", "text": truncate(slackMaxHeaderChars, text), "emoji": true}
+}
+
+// slackEscape escapes the characters Slack treats as control sequences in mrkdwn
+func slackEscape(text string) string {
+	return strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;").Replace(text)
+}
@@ -0,0 +1,134 @@
+package report
+
+import (
+	"fmt"
+	"strings"
+
+	"daily_report/pkg/models"
+)
+
+// FormatTeams renders Microsoft Teams Adaptive Card messages
+const FormatTeams = "teams"
+
+// teamsMaxBytes is the Teams incoming webhook message size limit
+const teamsMaxBytes = 28 * 1024
+
+// teamsElement is an Adaptive Card element or action
+type teamsElement map[string]interface{}
+
+// TeamsCards renders the report as one or more Teams incoming webhook messages
+// carrying an Adaptive Card. Each returned payload is within the size limit.
+func (g *Generator) TeamsCards(data *models.ReportData, opts CardOptions) ([][]byte, error) {
+	maxBytes := opts.MaxBytes
+	if maxBytes <= 0 {
+		maxBytes = teamsMaxBytes
+	}
+
+	all, sections := g.cardSections(data)
+	title := g.cardTitle(data)
+
+	footer := []teamsElement{{
+		"type":      "TextBlock",
+		"text":      sourceStatusLine(data.SourceStatus),
+		"isSubtle":  true,
+		"size":      "Small",
+		"wrap":      true,
+		"separator": true,
+	}}
+	var actions []teamsElement
+	if opts.ReportURL != "" {
+		actions = append(actions, teamsElement{"type": "Action.OpenUrl", "title": "查看完整日报", "url": opts.ReportURL})
+	}
+
+	var blocks [][]teamsElement
+	for _, s := range sections {
+		chunks := chunkLines(g.cardSectionLines(s, markdownMarkup), maxBytes/2)
+		for i, chunk := range chunks {
+			heading := fmt.Sprintf("%s (%d)", s.Heading, s.Count)
+			if i > 0 {
+				heading = fmt.Sprintf("%s (续)", s.Heading)
+			}
+			blocks = append(blocks, []teamsElement{
+				{"type": "TextBlock", "text": heading, "weight": "Bolder", "size": "Medium", "wrap": true, "separator": true},
+				{"type": "TextBlock", "text": strings.Join(chunk, "\n"), "wrap": true},
+			})
+		}
+	}
+
+	pages := [][]teamsElement{{teamsStats(all)}}
+	for _, block := range blocks {
+		current := pages[len(pages)-1]
+		candidate := append(append([]teamsElement{}, current...), block...)
+		size, err := teamsSize(title+" (99/99)", append(candidate, footer...), actions)
+		if err != nil {
+			return nil, err
+		}
+		if size > maxBytes && len(current) > 0 {
+			pages = append(pages, block)
+			continue
+		}
+		pages[len(pages)-1] = candidate
+	}
+	pages[len(pages)-1] = append(pages[len(pages)-1], footer...)
+
+	messages := make([][]byte, 0, len(pages))
+	for i, body := range pages {
+		var pageActions []teamsElement
+		if i == len(pages)-1 {
+			pageActions = actions
+		}
+		msg, err := marshalPayload(teamsMessage(pagedTitle(title, i+1, len(pages)), body, pageActions))
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode Teams card: %w", err)
+		}
+		messages = append(messages, msg)
+	}
+
+	return messages, nil
+}
+
+// teamsStats renders section counts as a fact set
+func teamsStats(sections []Section) teamsElement {
+	facts := make([]teamsElement, 0, len(sections))
+	for _, s := range sections {
+		facts = append(facts, teamsElement{"title": s.Label, "value": fmt.Sprintf("%d", s.Count)})
+	}
+	return teamsElement{"type": "FactSet", "facts": facts}
+}
+
+// teamsMessage wraps card body and actions into an incoming webhook payload
+func teamsMessage(title string, body, actions []teamsElement) map[string]interface{} {
+	card := map[string]interface{}{
+		"$schema": "http://adaptivecards.io/schemas/adaptive-card.json",
+		"type":    "AdaptiveCard",
+		"version": "1.4",
+		"msteams": map[string]string{"width": "Full"},
+		"body": append([]teamsElement{{
+			"type":   "TextBlock",
+			"text":   title,
+			"size":   "Large",
+			"weight": "Bolder",
+			"wrap":   true,
+		}}, body...),
+	}
+	if len(actions) > 0 {
+		card["actions"] = actions
+	}
+
+	return map[string]interface{}{
+		"type": "message",
+		"attachments": []map[string]interface{}{{
+			"contentType": "application/vnd.microsoft.card.adaptive",
+			"content":     card,
+		}},
+	}
+}
+
+// teamsSize returns the encoded size of a message with the given body and actions
+func teamsSize(title string, body, actions []teamsElement) (int, error) {
+	msg, err := marshalPayload(teamsMessage(title, body, actions))
+	if err != nil {
+		return 0, fmt.Errorf("failed to encode Teams card: %w", err)
+	}
+	return len(msg), nil
+}
@@ -1,12 +1,13 @@
 package report
 
 import (
+	_ "embed"
 	"fmt"
-	"os"
 	"sort"
 	"strings"
 	"time"
 
+	"daily_report/internal/config"
 	"daily_report/pkg/models"
 )
 
@@ -14,6 +15,15 @@ import (
 type Generator struct {
 	template           string
 	customTemplatePath string
+	engine             string
+	sections           []config.SectionConfig
+	loader             *TemplateLoader
+	strict             bool
+	requiredSections   []string
+	skipEmptyDays      bool
+	location           *time.Location
+	showSourceTimezone bool
+	warnings           []Issue
 }
 
 // NewGenerator creates a new report generator with default template
@@ -30,17 +40,41 @@ func NewGeneratorWithTemplate(template string) *Generator {
 	}
 }
 
-// NewGeneratorWithTemplatePath creates a new report generator that loads template from file
+// NewGeneratorWithTemplatePath creates a new report generator that loads template from a
+// file or directory path, or by name through the template search path
 func NewGeneratorWithTemplatePath(templatePath string) *Generator {
 	return &Generator{
 		customTemplatePath: templatePath,
+		loader:             NewTemplateLoader(DefaultSearchPath()...),
 	}
 }
 
+// SetLoader replaces the loader used to resolve the template path or name
+func (g *Generator) SetLoader(loader *TemplateLoader) {
+	g.loader = loader
+}
+
+// SetEngine selects the template engine: auto (default), legacy or go
+func (g *Generator) SetEngine(engine string) {
+	g.engine = engine
+}
+
 // Generate generates a markdown report from the collected data
-func (g *Generator) Generate(data *models.ReportData) string {
-	// Load template
-	template := g.getTemplate()
+func (g *Generator) Generate(data *models.ReportData) (string, error) {
+	// Load and validate template
+	template, err := g.getTemplate()
+	if err != nil {
+		return "", err
+	}
+
+	g.warnings = nil
+	issues := g.validateTemplate(template)
+	for _, issue := range issues {
+		if issue.Severity == SeverityError || g.strict {
+			return "", fmt.Errorf("invalid template:\n%s", formatIssues(issues))
+		}
+	}
+	g.warnings = issues
 
 	// Group items by type
 	itemsByType := g.groupItemsByType(data.Items)
@@ -55,17 +89,16 @@ func (g *Generator) Generate(data *models.ReportData) string {
 	return g.renderTemplate(data, itemsByType, stats, sourceStatus, template)
 }
 
-// getTemplate returns the template to use (from file or default)
-func (g *Generator) getTemplate() string {
+// getTemplate returns the template to use (from file, search path or default)
+func (g *Generator) getTemplate() (*Template, error) {
 	if g.customTemplatePath != "" {
-		data, err := os.ReadFile(g.customTemplatePath)
+		tpl, err := g.loader.Load(g.customTemplatePath)
 		if err != nil {
-			// Fall back to default template if file read fails
-			return defaultTemplate
+			return nil, fmt.Errorf("failed to load template: %w", err)
 		}
-		return string(data)
+		return tpl, nil
 	}
-	return g.template
+	return &Template{Main: g.template}, nil
 }
 
 // groupItemsByType groups items by their type and sorts by time
@@ -119,50 +152,17 @@ func (g *Generator) buildSourceStatus(status map[string]models.SourceStatus) str
 }
 
 // renderTemplate renders the report using the template
-func (g *Generator) renderTemplate(data *models.ReportData, itemsByType map[string][]models.Item, stats map[string]int, sourceStatus string, template string) string {
-	// For custom templates, use simple placeholder replacement
-	if g.customTemplatePath != "" {
-		return g.renderCustomTemplate(data, itemsByType, stats, sourceStatus, template)
-	}
-
-	// Use default template renderer
-	var sb strings.Builder
-
-	// Title and date
-	sb.WriteString(fmt.Sprintf("# 日报 - %s\n\n", data.Date.Format("2006年1月2日")))
-
-	// Summary stats
-	sb.WriteString("## 📊 汇总统计\n\n")
-	sb.WriteString(fmt.Sprintf("- Git 提交: %d 次\n", stats["git"]))
-	sb.WriteString(fmt.Sprintf("- 会议: %d 场\n", stats["meeting"]))
-	sb.WriteString(fmt.Sprintf("- Jira 任务: %d 个\n", stats["jira"]))
-	sb.WriteString(fmt.Sprintf("- Confluence 文档: %d 篇\n\n", stats["confluence"]))
-
-	// Git commits
-	if items, ok := itemsByType["git"]; ok && len(items) > 0 {
-		sb.WriteString(g.renderGitItems(items))
-	}
-
-	// Meetings
-	if items, ok := itemsByType["meeting"]; ok && len(items) > 0 {
-		sb.WriteString(g.renderMeetingItems(items))
-	}
-
-	// Jira tasks
-	if items, ok := itemsByType["jira"]; ok && len(items) > 0 {
-		sb.WriteString(g.renderJiraItems(items))
-	}
-
-	// Confluence docs
-	if items, ok := itemsByType["confluence"]; ok && len(items) > 0 {
-		sb.WriteString(g.renderConfluenceItems(items))
+func (g *Generator) renderTemplate(data *models.ReportData, itemsByType map[string][]models.Item, stats map[string]int, sourceStatus string, tpl *Template) (string, error) {
+	// Templates are rendered by the selected engine
+	if tpl.Main != "" || len(tpl.Partials) > 0 {
+		if len(tpl.Partials) > 0 || g.resolveEngine(tpl.Main) == EngineGo {
+			return g.renderGoTemplate(data, itemsByType, stats, tpl)
+		}
+		return g.renderCustomTemplate(data, itemsByType, stats, sourceStatus, tpl.Main), nil
 	}
 
-	// Footer
-	sb.WriteString(fmt.Sprintf("\n---\n\n生成于: %s\n数据源状态: %s\n",
-		time.Now().Format("2006-01-02 15:04:05"), sourceStatus))
-
-	return sb.String()
+	// An empty template falls back to the built-in default
+	return g.renderGoTemplate(data, itemsByType, stats, &Template{Name: "default", Main: defaultTemplate})
 }
 
 // renderCustomTemplate renders using custom template with placeholders
@@ -170,9 +170,9 @@ func (g *Generator) renderCustomTemplate(data *models.ReportData, itemsByType ma
 	result := template
 
 	// Replace basic placeholders
-	result = strings.ReplaceAll(result, "{{date}}", data.Date.Format("2006年1月2日"))
-	result = strings.ReplaceAll(result, "{{date_en}}", data.Date.Format("2006-01-02"))
-	result = strings.ReplaceAll(result, "{{generate_time}}", time.Now().Format("2006-01-02 15:04:05"))
+	result = strings.ReplaceAll(result, "{{date}}", g.formatDate("2006年1月2日", data.Date))
+	result = strings.ReplaceAll(result, "{{date_en}}", g.formatDate("2006-01-02", data.Date))
+	result = strings.ReplaceAll(result, "{{generate_time}}", g.now().Format("2006-01-02 15:04:05"))
 	result = strings.ReplaceAll(result, "{{source_status}}", sourceStatus)
 
 	// Replace stats
@@ -194,7 +194,7 @@ func (g *Generator) renderCustomTemplate(data *models.ReportData, itemsByType ma
 func (g *Generator) renderGitItems(items []models.Item) string {
 	var sb strings.Builder
 
-	sb.WriteString("## 💻 代码提交\n\n")
+	sb.WriteString(fmt.Sprintf("## %s\n\n", g.sectionConfig("git").Heading))
 
 	// Group by repo
 	byRepo := make(map[string][]models.Item)
@@ -203,12 +203,19 @@ func (g *Generator) renderGitItems(items []models.Item) string {
 		byRepo[repo] = append(byRepo[repo], item)
 	}
 
-	for repo, commits := range byRepo {
+	repos := make([]string, 0, len(byRepo))
+	for repo := range byRepo {
+		repos = append(repos, repo)
+	}
+	sort.Strings(repos)
+
+	for _, repo := range repos {
+		commits := byRepo[repo]
 		sb.WriteString(fmt.Sprintf("### %s\n\n", repo))
 		for _, commit := range commits {
 			sb.WriteString(fmt.Sprintf("- %s (%s)\n",
 				commit.Title,
-				commit.Time.Format("15:04")))
+				g.clock(commit.Time)))
 			if commitLink, ok := commit.Metadata["commit"].(string); ok {
 				sb.WriteString(fmt.Sprintf("  commit: %s\n", commitLink[:7]))
 			}
@@ -223,11 +230,11 @@ func (g *Generator) renderGitItems(items []models.Item) string {
 func (g *Generator) renderMeetingItems(items []models.Item) string {
 	var sb strings.Builder
 
-	sb.WriteString("## 📅 会议\n\n")
+	sb.WriteString(fmt.Sprintf("## %s\n\n", g.sectionConfig("meeting").Heading))
 
 	for _, item := range items {
 		sb.WriteString(fmt.Sprintf("### %s - %s\n",
-			item.Time.Format("15:04"),
+			g.clock(item.Time),
 			item.Title))
 		sb.WriteString(fmt.Sprintf("- 参会者: %s\n", item.Content))
 		if item.Link != "" {
@@ -243,14 +250,14 @@ func (g *Generator) renderMeetingItems(items []models.Item) string {
 func (g *Generator) renderJiraItems(items []models.Item) string {
 	var sb strings.Builder
 
-	sb.WriteString("## 🎯 Jira 任务\n\n")
+	sb.WriteString(fmt.Sprintf("## %s\n\n", g.sectionConfig("jira").Heading))
 
 	for _, item := range items {
 		sb.WriteString(fmt.Sprintf("### %s\n", item.Title))
 		if status, ok := item.Metadata["status"].(string); ok {
 			sb.WriteString(fmt.Sprintf("- 状态: %s\n", status))
 		}
-		sb.WriteString(fmt.Sprintf("- 更新时间: %s\n", item.Time.Format("15:04")))
+		sb.WriteString(fmt.Sprintf("- 更新时间: %s\n", g.clock(item.Time)))
 		if item.Link != "" {
 			sb.WriteString(fmt.Sprintf("- 链接: %s\n", item.Link))
 		}
@@ -264,12 +271,33 @@ func (g *Generator) renderJiraItems(items []models.Item) string {
 func (g *Generator) renderConfluenceItems(items []models.Item) string {
 	var sb strings.Builder
 
-	sb.WriteString("## 📝 Confluence 文档\n\n")
+	sb.WriteString(fmt.Sprintf("## %s\n\n", g.sectionConfig("confluence").Heading))
 
 	for _, item := range items {
 		sb.WriteString(fmt.Sprintf("### %s\n", item.Title))
 		sb.WriteString(fmt.Sprintf("- 作者: %s\n", item.Content))
-		sb.WriteString(fmt.Sprintf("- 更新时间: %s\n", item.Time.Format("15:04")))
+		sb.WriteString(fmt.Sprintf("- 更新时间: %s\n", g.clock(item.Time)))
+		if item.Link != "" {
+			sb.WriteString(fmt.Sprintf("- 链接: %s\n", item.Link))
+		}
+		sb.WriteString("\n")
+	}
+
+	return sb.String()
+}
+
+// renderGenericItems renders items of types without a dedicated renderer
+func (g *Generator) renderGenericItems(typ string, items []models.Item) string {
+	var sb strings.Builder
+
+	sb.WriteString(fmt.Sprintf("## %s\n\n", g.sectionConfig(typ).Heading))
+
+	for _, item := range items {
+		sb.WriteString(fmt.Sprintf("### %s\n", item.Title))
+		sb.WriteString(fmt.Sprintf("- 时间: %s\n", g.clock(item.Time)))
+		if item.Content != "" {
+			sb.WriteString(fmt.Sprintf("- 内容: %s\n", item.Content))
+		}
 		if item.Link != "" {
 			sb.WriteString(fmt.Sprintf("- 链接: %s\n", item.Link))
 		}
@@ -279,5 +307,7 @@ func (g *Generator) renderConfluenceItems(items []models.Item) string {
 	return sb.String()
 }
 
-// defaultTemplate is the default markdown template
-const defaultTemplate = ""
+// defaultTemplate is the built-in default markdown template
+//
+//go:embed templates/default.md.tmpl
+var defaultTemplate string
@@ -134,7 +134,10 @@ func TestGenerator_Generate_Empty(t *testing.T) {
 		SourceStatus: map[string]models.SourceStatus{},
 	}
 
-	result := gen.Generate(reportData)
+	result, err := gen.Generate(reportData)
+	if err != nil {
+		t.Fatalf("Generate failed: %v", err)
+	}
 
 	if result == "" {
 		t.Error("Generate should return non-empty result")
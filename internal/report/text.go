@@ -0,0 +1,130 @@
+package report
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+
+	"daily_report/pkg/models"
+)
+
+// FormatText renders a compact numbered plain-text summary for chat messages
+const FormatText = "text"
+
+// commitPrefixPattern matches a conventional commit prefix such as "fix(auth):" or a "[tag]" prefix
+var commitPrefixPattern = regexp.MustCompile(`^(\[[^\]]+\]|[A-Za-z]+(\([^)]*\))?!?:)\s*`)
+
+// GenerateText generates a numbered one-line-per-item summary.
+// Commits in the same repository with the same prefix are merged into one line.
+// When maxChars is positive the output is cut to at most maxChars characters.
+func (g *Generator) GenerateText(data *models.ReportData, maxChars int) string {
+	itemsByType := g.groupItemsByType(data.Items)
+
+	var lines []string
+	for _, s := range g.buildSections(itemsByType) {
+		if s.Type == "git" {
+			lines = append(lines, compactCommitLines(s.Items)...)
+			continue
+		}
+		for _, item := range s.Items {
+			line := fmt.Sprintf("%s: %s", s.Label, item.Title)
+			if status := meta("status", item); status != "" {
+				line += fmt.Sprintf(" (%s)", status)
+			}
+			lines = append(lines, line)
+		}
+	}
+
+	header := g.reportTitle(data, "2006-01-02", "2006-01")
+	if len(lines) == 0 {
+		return header + "\n无工作产出\n"
+	}
+
+	numbered := make([]string, len(lines))
+	for i, line := range lines {
+		numbered[i] = fmt.Sprintf("%d. %s", i+1, line)
+	}
+
+	return limitLines(header, numbered, maxChars)
+}
+
+// compactCommitLines merges commits sharing repository and prefix, keeping first-seen order
+func compactCommitLines(items []models.Item) []string {
+	type group struct {
+		repo   string
+		prefix string
+		titles []string
+	}
+
+	var groups []*group
+	index := make(map[string]*group)
+	for _, item := range items {
+		repo := meta("repo", item)
+		prefix := strings.TrimSpace(commitPrefixPattern.FindString(item.Title))
+		title := strings.TrimSpace(item.Title[len(commitPrefixPattern.FindString(item.Title)):])
+
+		key := repo + "\x00" + prefix
+		if prefix == "" {
+			// Commits without a prefix are never merged
+			key += "\x00" + fmt.Sprint(len(groups))
+		}
+
+		grp, ok := index[key]
+		if !ok {
+			grp = &group{repo: repo, prefix: prefix}
+			index[key] = grp
+			groups = append(groups, grp)
+		}
+		grp.titles = append(grp.titles, title)
+	}
+
+	lines := make([]string, 0, len(groups))
+	for _, grp := range groups {
+		line := fmt.Sprintf("[%s] ", grp.repo)
+		if grp.prefix != "" {
+			line += grp.prefix + " "
+		}
+		line += strings.Join(grp.titles, "；")
+		lines = append(lines, line)
+	}
+	return lines
+}
+
+// limitLines joins header and lines, cutting lines and noting how many were left out
+// so that the result is at most maxChars characters
+func limitLines(header string, lines []string, maxChars int) string {
+	full := header + "\n" + strings.Join(lines, "\n") + "\n"
+	if maxChars <= 0 || utf8.RuneCountInString(full) <= maxChars {
+		return full
+	}
+
+	out := header + "\n"
+	for i, line := range lines {
+		notice := ""
+		if remaining := len(lines) - i - 1; remaining > 0 {
+			notice = fmt.Sprintf("…另有 %d 项\n", remaining)
+		}
+
+		if utf8.RuneCountInString(out+line+"\n"+notice) <= maxChars {
+			out += line + "\n"
+			continue
+		}
+
+		// Shorten the line that does not fit; drop it if too little room is left
+		room := maxChars - utf8.RuneCountInString(out+notice) - 1
+		if room >= minTextLineChars {
+			out += truncate(room, line) + "\n"
+		} else {
+			notice = fmt.Sprintf("…另有 %d 项\n", len(lines)-i)
+		}
+		out += notice
+		break
+	}
+
+	// Guard against limits smaller than the header and notice themselves
+	return truncate(maxChars, out)
+}
+
+// minTextLineChars is the shortest truncated line worth keeping
+const minTextLineChars = 10
@@ -0,0 +1,71 @@
+package report
+
+import (
+	"strings"
+	"testing"
+	"time"
+	"unicode/utf8"
+
+	"daily_report/pkg/models"
+)
+
+func testTextData() *models.ReportData {
+	base := time.Date(2026, 10, 18, 9, 0, 0, 0, time.UTC)
+	commit := func(repo, title string, minutes int) models.Item {
+		return models.Item{Type: "git", Title: title, Time: base.Add(time.Duration(minutes) * time.Minute),
+			Metadata: map[string]interface{}{"repo": repo, "commit": "abcdef1234"}}
+	}
+
+	return &models.ReportData{
+		Date: base,
+		Items: []models.Item{
+			commit("api", "fix(auth): login timeout", 50),
+			commit("api", "fix(auth): token refresh", 40),
+			commit("api", "feat: export csv", 30),
+			commit("web", "fix(auth): login page", 20),
+			commit("api", "bump deps", 10),
+			{Type: "jira", Title: "PROJ-1 登录超时", Time: base, Metadata: map[string]interface{}{"status": "Done"}},
+		},
+	}
+}
+
+func TestGenerateText(t *testing.T) {
+	result := NewGenerator().GenerateText(testTextData(), 0)
+
+	expected := `日报 - 2026-10-18
+1. [api] fix(auth): login timeout；token refresh
+2. [api] feat: export csv
+3. [web] fix(auth): login page
+4. [api] bump deps
+5. Jira 任务: PROJ-1 登录超时 (Done)
+`
+	if result != expected {
+		t.Errorf("Unexpected text output:\n%s\nwant:\n%s", result, expected)
+	}
+}
+
+func TestGenerateText_Empty(t *testing.T) {
+	result := NewGenerator().GenerateText(&models.ReportData{Date: testTextData().Date}, 0)
+	if result != "日报 - 2026-10-18\n无工作产出\n" {
+		t.Errorf("Unexpected empty output: %q", result)
+	}
+}
+
+func TestGenerateText_MaxChars(t *testing.T) {
+	full := NewGenerator().GenerateText(testTextData(), 0)
+
+	for _, limit := range []int{60, 80, 100, 5} {
+		result := NewGenerator().GenerateText(testTextData(), limit)
+
+		if n := utf8.RuneCountInString(result); n > limit {
+			t.Errorf("limit %d: output has %d characters:\n%s", limit, n, result)
+		}
+		if limit >= 60 && !strings.Contains(result, "…另有") {
+			t.Errorf("limit %d: expected a note about omitted items, got:\n%s", limit, result)
+		}
+	}
+
+	if result := NewGenerator().GenerateText(testTextData(), 1000); result != full {
+		t.Error("Output within the limit should not change")
+	}
+}
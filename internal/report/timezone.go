@@ -0,0 +1,58 @@
+package report
+
+import (
+	"fmt"
+	"time"
+
+	"daily_report/pkg/models"
+)
+
+// SetLocation sets the report timezone item times are rendered in; nil keeps each time's own offset
+func (g *Generator) SetLocation(loc *time.Location) {
+	g.location = loc
+}
+
+// SetShowSourceTimezone appends the original time and offset to items recorded in another timezone
+func (g *Generator) SetShowSourceTimezone(show bool) {
+	g.showSourceTimezone = show
+}
+
+// localTime converts t into the report timezone
+func (g *Generator) localTime(t time.Time) time.Time {
+	if g.location == nil {
+		return t
+	}
+	return t.In(g.location)
+}
+
+// reportLocation returns the report timezone, defaulting to the location of the report start
+func (g *Generator) reportLocation(data *models.ReportData) *time.Location {
+	if g.location != nil {
+		return g.location
+	}
+	return data.StartTime.Location()
+}
+
+// formatDate formats t in the report timezone, e.g. {{.Date | formatDate "2006-01-02"}}
+func (g *Generator) formatDate(layout string, t time.Time) string {
+	return g.localTime(t).Format(layout)
+}
+
+// clock renders an item time as HH:MM in the report timezone, followed by the
+// original time when enabled and the item was recorded at another offset, e.g. "23:30 (08:30 UTC-07:00)"
+func (g *Generator) clock(t time.Time) string {
+	local := g.localTime(t)
+	s := local.Format("15:04")
+
+	_, sourceOffset := t.Zone()
+	_, localOffset := local.Zone()
+	if g.showSourceTimezone && sourceOffset != localOffset {
+		s += fmt.Sprintf(" (%s UTC%s)", t.Format("15:04"), t.Format("-07:00"))
+	}
+	return s
+}
+
+// now returns the current time in the report timezone
+func (g *Generator) now() time.Time {
+	return g.localTime(time.Now())
+}
@@ -0,0 +1,79 @@
+package report
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"daily_report/pkg/models"
+)
+
+func TestGenerator_Clock(t *testing.T) {
+	shanghai := time.FixedZone("CST", 8*3600)
+	pacific := time.FixedZone("PDT", -7*3600)
+	commit := time.Date(2026, 10, 18, 8, 30, 0, 0, pacific)
+
+	gen := NewGenerator()
+	if got := gen.clock(commit); got != "08:30" {
+		t.Errorf("Without a location the original offset should be kept, got %q", got)
+	}
+
+	gen.SetLocation(shanghai)
+	if got := gen.clock(commit); got != "23:30" {
+		t.Errorf("Expected time in report timezone, got %q", got)
+	}
+
+	gen.SetShowSourceTimezone(true)
+	if got := gen.clock(commit); got != "23:30 (08:30 UTC-07:00)" {
+		t.Errorf("Expected original time to be shown, got %q", got)
+	}
+	if got := gen.clock(commit.In(shanghai)); got != "23:30" {
+		t.Errorf("Original time should only be shown when the offset differs, got %q", got)
+	}
+}
+
+func TestGenerate_ReportTimezone(t *testing.T) {
+	shanghai := time.FixedZone("CST", 8*3600)
+	data := testGoTemplateData()
+	data.Items = []models.Item{
+		{Type: "git", Title: "fix: remote commit", Time: time.Date(2026, 10, 18, 1, 15, 0, 0, time.UTC),
+			Metadata: map[string]interface{}{"repo": "api", "commit": "abcdef1234"}},
+	}
+
+	gen := NewGenerator()
+	gen.SetLocation(shanghai)
+
+	result, err := gen.Generate(data)
+	if err != nil {
+		t.Fatalf("Generate failed: %v", err)
+	}
+	if !strings.Contains(result, "fix: remote commit (09:15)") {
+		t.Errorf("Expected commit time in report timezone, got:\n%s", result)
+	}
+
+	html, err := gen.GenerateHTML(data)
+	if err != nil {
+		t.Fatalf("GenerateHTML failed: %v", err)
+	}
+	if !strings.Contains(html, "09:15") {
+		t.Errorf("Expected HTML time in report timezone, got:\n%s", html)
+	}
+}
+
+func TestGenerate_LegacyDatesInReportTimezone(t *testing.T) {
+	data := testGoTemplateData()
+	// 2026-10-18 20:00 in UTC is already 10-19 in Shanghai
+	data.Date = time.Date(2026, 10, 18, 20, 0, 0, 0, time.UTC)
+
+	gen := NewGeneratorWithTemplate("{{date}} {{date_en}}")
+	gen.SetEngine(EngineLegacy)
+	gen.SetLocation(time.FixedZone("CST", 8*3600))
+
+	result, err := gen.Generate(data)
+	if err != nil {
+		t.Fatalf("Generate failed: %v", err)
+	}
+	if result != "2026年10月19日 2026-10-19" {
+		t.Errorf("Expected dates in the report timezone, got %q", result)
+	}
+}
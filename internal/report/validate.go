@@ -0,0 +1,360 @@
+package report
+
+import (
+	"fmt"
+	"io"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"text/template"
+	"text/template/parse"
+	"time"
+
+	"daily_report/internal/aggregate"
+	"daily_report/pkg/models"
+)
+
+// Issue severities
+const (
+	SeverityError   = "error"
+	SeverityWarning = "warning"
+)
+
+// Issue is a problem found while validating a template
+type Issue struct {
+	Template string
+	Line     int
+	Column   int
+	Severity string
+	Message  string
+}
+
+// String formats the issue as template:line:column: severity: message
+func (i Issue) String() string {
+	pos := i.Template
+	if i.Line > 0 {
+		pos += ":" + strconv.Itoa(i.Line)
+		if i.Column > 0 {
+			pos += ":" + strconv.Itoa(i.Column)
+		}
+	}
+	return fmt.Sprintf("%s: %s: %s", pos, i.Severity, i.Message)
+}
+
+// legacyPlaceholders lists every placeholder understood by the legacy engine
+var legacyPlaceholders = map[string]bool{
+	"date":               true,
+	"date_en":            true,
+	"generate_time":      true,
+	"source_status":      true,
+	"git_count":          true,
+	"meeting_count":      true,
+	"jira_count":         true,
+	"confluence_count":   true,
+	"git_section":        true,
+	"meeting_section":    true,
+	"jira_section":       true,
+	"confluence_section": true,
+}
+
+var (
+	// templateErrorPattern extracts name, line and optional column from text/template errors
+	templateErrorPattern = regexp.MustCompile(`^template: ([^:]+):(\d+):(?:(\d+):)? (.*)$`)
+	// quotedNamePattern finds the first quoted identifier in an error message
+	quotedNamePattern = regexp.MustCompile(`"([A-Za-z_][A-Za-z0-9_]*)"`)
+)
+
+// SetStrict makes generation fail on template warnings such as missing sections instead of continuing
+func (g *Generator) SetStrict(strict bool) {
+	g.strict = strict
+}
+
+// SetRequiredSections sets the item types every template must render
+func (g *Generator) SetRequiredSections(sections []string) {
+	g.requiredSections = sections
+}
+
+// Warnings returns the template warnings found by the last call to Generate
+func (g *Generator) Warnings() []Issue {
+	return g.warnings
+}
+
+// Validate loads the configured template and checks it for problems
+func (g *Generator) Validate() ([]Issue, error) {
+	tpl, err := g.getTemplate()
+	if err != nil {
+		return nil, err
+	}
+	return g.validateTemplate(tpl), nil
+}
+
+// validateTemplate checks a loaded template for syntax errors, unknown placeholders and missing sections
+func (g *Generator) validateTemplate(tpl *Template) []Issue {
+	if tpl.Main == "" && len(tpl.Partials) == 0 {
+		return nil
+	}
+
+	if len(tpl.Partials) == 0 && g.resolveEngine(tpl.Main) == EngineLegacy {
+		return g.validateLegacyTemplate(tpl)
+	}
+	return g.validateGoTemplate(tpl)
+}
+
+// validateLegacyTemplate reports unknown placeholders and missing sections in a legacy template
+func (g *Generator) validateLegacyTemplate(tpl *Template) []Issue {
+	var issues []Issue
+
+	for _, loc := range actionPattern.FindAllStringSubmatchIndex(tpl.Main, -1) {
+		name := tpl.Main[loc[2]:loc[3]]
+		if legacyPlaceholders[name] {
+			continue
+		}
+		line, col := position(tpl.Main, loc[0])
+		issues = append(issues, Issue{
+			Template: templateName(tpl),
+			Line:     line,
+			Column:   col,
+			Severity: SeverityError,
+			Message:  fmt.Sprintf("unknown placeholder {{%s}}", name),
+		})
+	}
+
+	for _, section := range g.requiredSections {
+		if !strings.Contains(tpl.Main, "{{"+section+"_section}}") {
+			issues = append(issues, Issue{
+				Template: templateName(tpl),
+				Severity: SeverityWarning,
+				Message:  fmt.Sprintf("missing required section {{%s_section}}", section),
+			})
+		}
+	}
+
+	return issues
+}
+
+// validateGoTemplate reports syntax errors, dry-run failures and missing sections in a Go template
+func (g *Generator) validateGoTemplate(tpl *Template) []Issue {
+	sources := map[string]string{templateName(tpl): tpl.Main}
+	for _, p := range tpl.Partials {
+		sources[p.Name] = p.Source
+	}
+
+	data := sampleReportData()
+	itemsByType := g.groupItemsByType(data.Items)
+
+	t := g.newGoTemplate(templateName(tpl), data, itemsByType)
+	if _, err := t.Parse(tpl.Main); err != nil {
+		return []Issue{templateErrorIssue(err, templateName(tpl), sources, SeverityError)}
+	}
+	for _, p := range tpl.Partials {
+		if _, err := t.New(p.Name).Parse(p.Source); err != nil {
+			return []Issue{templateErrorIssue(err, p.Name, sources, SeverityError)}
+		}
+	}
+
+	var issues []Issue
+
+	// Execute against sample data to catch unknown fields and bad function arguments
+	view := *data
+	view.ItemsByType = itemsByType
+	view.Stats = g.calculateStats(itemsByType)
+	if err := t.Execute(io.Discard, &view); err != nil {
+		issues = append(issues, templateErrorIssue(err, templateName(tpl), sources, SeverityError))
+	}
+
+	// Only templates reached from the main template render anything; a defined
+	// template counts through the sections it references, not through its name
+	refs := make(map[string]bool)
+	w := &sectionRefWalker{lookup: t.Lookup, refs: refs, visited: make(map[string]bool)}
+	w.template(templateName(tpl))
+	for _, section := range g.requiredSections {
+		if !refs[section] && !refs[allSections] {
+			issues = append(issues, Issue{
+				Template: templateName(tpl),
+				Severity: SeverityWarning,
+				Message:  fmt.Sprintf("missing required section %q", section),
+			})
+		}
+	}
+
+	return issues
+}
+
+// allSections marks a template that renders every visible section through the sections function
+const allSections = "*"
+
+// sectionRefWalker records item types referenced by section calls and ItemsByType lookups,
+// following template calls into the templates they execute
+type sectionRefWalker struct {
+	lookup  func(name string) *template.Template
+	refs    map[string]bool
+	visited map[string]bool
+}
+
+// template walks a named template once
+func (w *sectionRefWalker) template(name string) {
+	if w.visited[name] {
+		return
+	}
+	w.visited[name] = true
+	if tt := w.lookup(name); tt != nil && tt.Tree != nil {
+		w.walk(tt.Tree.Root)
+	}
+}
+
+// walk records the references of a node and its children
+func (w *sectionRefWalker) walk(node parse.Node) {
+	switch n := node.(type) {
+	case *parse.ListNode:
+		if n == nil {
+			return
+		}
+		for _, child := range n.Nodes {
+			w.walk(child)
+		}
+	case *parse.ActionNode:
+		w.walk(n.Pipe)
+	case *parse.IfNode:
+		w.branch(&n.BranchNode)
+	case *parse.RangeNode:
+		w.branch(&n.BranchNode)
+	case *parse.WithNode:
+		w.branch(&n.BranchNode)
+	case *parse.TemplateNode:
+		w.walk(n.Pipe)
+		w.template(n.Name)
+	case *parse.PipeNode:
+		if n == nil {
+			return
+		}
+		for _, cmd := range n.Cmds {
+			w.walk(cmd)
+		}
+	case *parse.IdentifierNode:
+		if n.Ident == "sections" || n.Ident == "days" {
+			w.refs[allSections] = true
+		}
+	case *parse.CommandNode:
+		if len(n.Args) >= 2 {
+			if ident, ok := n.Args[0].(*parse.IdentifierNode); ok {
+				switch ident.Ident {
+				case "section":
+					if s, ok := n.Args[1].(*parse.StringNode); ok {
+						w.refs[s.Text] = true
+					}
+				case "index":
+					if len(n.Args) >= 3 && referencesItemsByType(n.Args[1]) {
+						if s, ok := n.Args[2].(*parse.StringNode); ok {
+							w.refs[s.Text] = true
+						}
+					}
+				}
+			}
+		}
+		for _, arg := range n.Args {
+			w.walk(arg)
+		}
+	case *parse.FieldNode:
+		for i := 0; i+1 < len(n.Ident); i++ {
+			if n.Ident[i] == "ItemsByType" {
+				w.refs[n.Ident[i+1]] = true
+			}
+		}
+	}
+}
+
+// branch walks the pipeline and both branches of if/range/with
+func (w *sectionRefWalker) branch(n *parse.BranchNode) {
+	w.walk(n.Pipe)
+	w.walk(n.List)
+	w.walk(n.ElseList)
+}
+
+// referencesItemsByType reports whether node is a .ItemsByType field access
+func referencesItemsByType(node parse.Node) bool {
+	field, ok := node.(*parse.FieldNode)
+	return ok && len(field.Ident) > 0 && field.Ident[len(field.Ident)-1] == "ItemsByType"
+}
+
+// templateErrorIssue converts a text/template error into an Issue with its position
+func templateErrorIssue(err error, fallbackName string, sources map[string]string, severity string) Issue {
+	issue := Issue{
+		Template: fallbackName,
+		Severity: severity,
+		Message:  err.Error(),
+	}
+
+	m := templateErrorPattern.FindStringSubmatch(err.Error())
+	if m == nil {
+		return issue
+	}
+
+	issue.Template = m[1]
+	issue.Line, _ = strconv.Atoi(m[2])
+	issue.Column, _ = strconv.Atoi(m[3])
+	issue.Message = m[4]
+
+	// Parse errors carry no column; locate the offending name on its line instead
+	if issue.Column == 0 {
+		if name := quotedNamePattern.FindStringSubmatch(issue.Message); name != nil {
+			lines := strings.Split(sources[issue.Template], "\n")
+			if issue.Line <= len(lines) {
+				if idx := strings.Index(lines[issue.Line-1], name[1]); idx >= 0 {
+					issue.Column = idx + 1
+				}
+			}
+		}
+	}
+
+	return issue
+}
+
+// position converts a byte offset into a 1-based line and column
+func position(s string, offset int) (int, int) {
+	before := s[:offset]
+	line := strings.Count(before, "\n") + 1
+	col := offset - strings.LastIndex(before, "\n")
+	return line, col
+}
+
+// templateName returns a display name for a template
+func templateName(tpl *Template) string {
+	if tpl.Name == "" {
+		return "report"
+	}
+	return tpl.Name
+}
+
+// formatIssues joins issues into a multi-line error message
+func formatIssues(issues []Issue) string {
+	lines := make([]string, 0, len(issues))
+	for _, issue := range issues {
+		lines = append(lines, issue.String())
+	}
+	sort.Strings(lines)
+	return strings.Join(lines, "\n")
+}
+
+// sampleReportData returns one item of each built-in type for template dry runs
+func sampleReportData() *models.ReportData {
+	now := time.Now()
+	items := []models.Item{
+		{Type: "git", Title: "feat: sample commit", Time: now, Link: "repo/commit/0000000000", Content: "author <author@example.com>",
+			Metadata: map[string]interface{}{"repo": "repo", "commit": "0000000000", "author": "author", "author_email": "author@example.com"}},
+		{Type: "meeting", Title: "Sample meeting", Time: now, Content: "author"},
+		{Type: "jira", Title: "PROJ-1 Sample issue", Time: now, Metadata: map[string]interface{}{"status": "Done"}},
+		{Type: "confluence", Title: "Sample page", Time: now, Content: "author"},
+	}
+
+	data := &models.ReportData{
+		Date:         now,
+		StartTime:    now,
+		EndTime:      now,
+		Items:        items,
+		Stats:        map[string]int{},
+		SourceStatus: map[string]models.SourceStatus{"git": {Name: "git", Success: true}},
+	}
+	data.Aggregate = aggregate.Build(data)
+	return data
+}
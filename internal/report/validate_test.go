@@ -0,0 +1,124 @@
+package report
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestValidate_LegacyUnknownPlaceholder(t *testing.T) {
+	gen := NewGeneratorWithTemplate("# {{date}}\n\n{{git_sectoin}}\n")
+
+	issues, err := gen.Validate()
+	if err != nil {
+		t.Fatalf("Validate failed: %v", err)
+	}
+
+	if len(issues) != 1 || issues[0].Severity != SeverityError {
+		t.Fatalf("Expected 1 error, got %v", issues)
+	}
+	if issues[0].Line != 3 || issues[0].Column != 1 {
+		t.Errorf("Expected position 3:1, got %d:%d", issues[0].Line, issues[0].Column)
+	}
+	if !strings.Contains(issues[0].Message, "git_sectoin") {
+		t.Errorf("Expected message to name the placeholder, got '%s'", issues[0].Message)
+	}
+}
+
+func TestValidate_GoSyntaxError(t *testing.T) {
+	gen := NewGeneratorWithTemplate("# {{.Date}}\n\n{{unknownFunc .Date}}\n")
+
+	issues, _ := gen.Validate()
+	if len(issues) != 1 || issues[0].Severity != SeverityError {
+		t.Fatalf("Expected 1 error, got %v", issues)
+	}
+	if issues[0].Line != 3 || issues[0].Column != 3 {
+		t.Errorf("Expected position 3:3, got %d:%d", issues[0].Line, issues[0].Column)
+	}
+}
+
+func TestValidate_GoUnknownField(t *testing.T) {
+	gen := NewGeneratorWithTemplate("{{range .Items}}{{.Titel}}{{end}}")
+
+	issues, _ := gen.Validate()
+	if len(issues) != 1 || issues[0].Severity != SeverityError || !strings.Contains(issues[0].Message, "Titel") {
+		t.Fatalf("Expected unknown field error, got %v", issues)
+	}
+
+	if _, err := gen.Generate(testGoTemplateData()); err == nil {
+		t.Error("Expected generation to fail on an unknown field")
+	}
+}
+
+func TestValidate_RequiredSections(t *testing.T) {
+	tests := []struct {
+		name     string
+		template string
+		missing  int
+	}{
+		{"Legacy present", "{{git_section}}", 1},
+		{"Go section call", `{{section "git"}}`, 1},
+		{"Go field access", `{{range .ItemsByType.git}}{{end}}`, 1},
+		{"Go index", `{{with index .ItemsByType "meeting"}}{{end}}`, 1},
+		{"Go both", `{{section "git"}}{{template "meeting" .}}{{define "meeting"}}{{range .ItemsByType.meeting}}{{end}}{{end}}`, 0},
+		{"Go empty define", `{{section "git"}}{{template "meeting" .}}{{define "meeting"}}{{end}}`, 1},
+		{"Go define not reached", `{{section "git"}}{{define "meeting"}}{{range .ItemsByType.meeting}}{{end}}{{end}}`, 1},
+		{"Go template argument", `{{section "git"}}{{template "list" .ItemsByType.meeting}}{{define "list"}}{{end}}`, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gen := NewGeneratorWithTemplate(tt.template)
+			gen.SetRequiredSections([]string{"git", "meeting"})
+
+			issues, _ := gen.Validate()
+			if len(issues) != tt.missing {
+				t.Errorf("Expected %d issues, got %v", tt.missing, issues)
+			}
+		})
+	}
+}
+
+func TestGenerate_StrictFailsOnWarnings(t *testing.T) {
+	gen := NewGeneratorWithTemplate("{{git_section}}")
+	gen.SetRequiredSections([]string{"git", "meeting"})
+
+	if _, err := gen.Generate(testGoTemplateData()); err != nil {
+		t.Fatalf("Non-strict generation should succeed, got %v", err)
+	}
+	if len(gen.Warnings()) != 1 {
+		t.Errorf("Expected 1 warning, got %v", gen.Warnings())
+	}
+
+	gen.SetStrict(true)
+	if _, err := gen.Generate(testGoTemplateData()); err == nil {
+		t.Error("Strict generation should fail on warnings")
+	}
+}
+
+func TestGenerate_UnknownPlaceholderIsError(t *testing.T) {
+	gen := NewGeneratorWithTemplate("{{git_sectoin}}")
+
+	if _, err := gen.Generate(testGoTemplateData()); err == nil || !strings.Contains(err.Error(), "git_sectoin") {
+		t.Errorf("Expected unknown placeholder to fail generation, got %v", err)
+	}
+}
+
+func TestGenerate_UnreadableTemplateIsError(t *testing.T) {
+	gen := NewGeneratorWithTemplatePath(filepath.Join(t.TempDir(), "missing", "template.md"))
+
+	if _, err := gen.Generate(testGoTemplateData()); err == nil {
+		t.Error("Expected error for missing template file instead of silent fallback")
+	}
+}
+
+func TestValidate_BuiltinDefault(t *testing.T) {
+	gen := NewGeneratorWithTemplatePath("default")
+	gen.SetLoader(NewTemplateLoader())
+	gen.SetRequiredSections([]string{"git", "meeting", "jira", "confluence"})
+
+	issues, err := gen.Validate()
+	if err != nil || len(issues) != 0 {
+		t.Errorf("Expected built-in default to validate cleanly, got %v, err=%v", issues, err)
+	}
+}
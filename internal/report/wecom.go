@@ -0,0 +1,88 @@
+package report
+
+import (
+	"fmt"
+	"strings"
+
+	"daily_report/pkg/models"
+)
+
+// FormatWeCom renders WeCom group robot markdown messages
+const FormatWeCom = "wecom"
+
+// weComMaxBytes is the WeCom robot markdown content limit
+const weComMaxBytes = 4096
+
+// WeComMessages renders the report as one or more WeCom group robot markdown messages.
+// WeCom has no buttons, so the report URL is appended as a link.
+func (g *Generator) WeComMessages(data *models.ReportData, opts CardOptions) ([][]byte, error) {
+	maxBytes := opts.MaxBytes
+	if maxBytes <= 0 {
+		maxBytes = weComMaxBytes
+	}
+
+	all, sections := g.cardSections(data)
+	title := g.cardTitle(data)
+
+	var stats []string
+	for _, s := range all {
+		stats = append(stats, fmt.Sprintf("%s **%d**", s.Label, s.Count))
+	}
+
+	footer := fmt.Sprintf("<font color=\"comment\">%s</font>", sourceStatusLine(data.SourceStatus))
+	if opts.ReportURL != "" {
+		footer += fmt.Sprintf("\n[查看完整日报](%s)", opts.ReportURL)
+	}
+
+	blocks := []string{strings.Join(stats, " · ")}
+	for _, s := range sections {
+		chunks := chunkLines(g.cardSectionLines(s, markdownMarkup), maxBytes/2)
+		for i, chunk := range chunks {
+			heading := fmt.Sprintf("#### %s (%d)", s.Heading, s.Count)
+			if i > 0 {
+				heading = fmt.Sprintf("#### %s (续)", s.Heading)
+			}
+			blocks = append(blocks, heading+"\n"+strings.Join(chunk, "\n"))
+		}
+	}
+
+	var pages [][]string
+	current := []string{}
+	for _, block := range blocks {
+		candidate := append(append([]string{}, current...), block)
+		if len(weComText(title+" (99/99)", candidate, footer)) > maxBytes && len(current) > 0 {
+			pages = append(pages, current)
+			current = []string{block}
+			continue
+		}
+		current = candidate
+	}
+	pages = append(pages, current)
+
+	messages := make([][]byte, 0, len(pages))
+	for i, page := range pages {
+		pageFooter := ""
+		if i == len(pages)-1 {
+			pageFooter = footer
+		}
+		msg, err := marshalPayload(map[string]interface{}{
+			"msgtype":  "markdown",
+			"markdown": map[string]string{"content": weComText(pagedTitle(title, i+1, len(pages)), page, pageFooter)},
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode WeCom message: %w", err)
+		}
+		messages = append(messages, msg)
+	}
+
+	return messages, nil
+}
+
+// weComText joins the title, blocks and optional footer into message Markdown
+func weComText(title string, blocks []string, footer string) string {
+	parts := append([]string{"### " + title}, blocks...)
+	if footer != "" {
+		parts = append(parts, footer)
+	}
+	return strings.Join(parts, "\n\n")
+}
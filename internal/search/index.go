@@ -0,0 +1,256 @@
+package search
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"daily_report/internal/history"
+	"daily_report/pkg/models"
+)
+
+// indexVersion is bumped whenever the on-disk format or tokenization changes
+const indexVersion = 2
+
+// textMetadata lists the metadata fields indexed besides title and content
+var textMetadata = []string{"body", "summary", "description"}
+
+// Doc is one indexed item
+type Doc struct {
+	Date    string    `json:"date"` // Day of the item in its report's timezone, YYYY-MM-DD
+	Time    time.Time `json:"time"`
+	Type    string    `json:"type"`
+	Title   string    `json:"title"`
+	Content string    `json:"content,omitempty"`
+	Link    string    `json:"link,omitempty"`
+	Repo    string    `json:"repo,omitempty"`
+}
+
+// Index is an inverted index from terms to the items of stored reports
+type Index struct {
+	Version  int                  `json:"version"`
+	Sources  map[string]time.Time `json:"sources"` // History entry files covered, with their modification time
+	Docs     []Doc                `json:"docs"`
+	Postings map[string][]int     `json:"postings"` // Term to ascending Doc indexes
+
+	warnings []string
+}
+
+// Build indexes the items of history entries; an item stored in several reports is indexed once
+func Build(entries []history.Entry) *Index {
+	idx := &Index{
+		Version:  indexVersion,
+		Postings: make(map[string][]int),
+	}
+
+	seen := make(map[string]bool)
+	for _, e := range entries {
+		if e.Data == nil {
+			continue
+		}
+		loc := e.Data.StartTime.Location()
+		for _, item := range e.Data.Items {
+			id := history.ItemID(item)
+			if seen[id] {
+				continue
+			}
+			seen[id] = true
+			idx.add(newDoc(item, loc), indexText(item))
+		}
+	}
+
+	return idx
+}
+
+// newDoc converts a report item into an indexed document
+func newDoc(item models.Item, loc *time.Location) Doc {
+	repo, _ := item.Metadata["repo"].(string)
+	return Doc{
+		Date:    item.Time.In(loc).Format(dateLayout),
+		Time:    item.Time,
+		Type:    item.Type,
+		Title:   item.Title,
+		Content: item.Content,
+		Link:    item.Link,
+		Repo:    repo,
+	}
+}
+
+// indexText returns the searchable text of an item
+func indexText(item models.Item) string {
+	parts := []string{item.Title, item.Content}
+	for _, key := range textMetadata {
+		if s, ok := item.Metadata[key].(string); ok {
+			parts = append(parts, s)
+		}
+	}
+	return strings.Join(parts, " ")
+}
+
+// add appends a document and the postings of its text
+func (idx *Index) add(d Doc, text string) {
+	id := len(idx.Docs)
+	idx.Docs = append(idx.Docs, d)
+
+	terms := make(map[string]bool)
+	for _, term := range indexTerms(text) {
+		terms[term] = true
+	}
+	for term := range terms {
+		idx.Postings[term] = append(idx.Postings[term], id)
+	}
+}
+
+// Search returns the documents containing every query term and passing its filters, newest first
+func (idx *Index) Search(q Query) []Doc {
+	var ids []int
+	if len(q.Terms) == 0 {
+		ids = make([]int, len(idx.Docs))
+		for i := range ids {
+			ids[i] = i
+		}
+	} else {
+		for i, term := range q.Terms {
+			postings := idx.Postings[term]
+			if i == 0 {
+				ids = append([]int{}, postings...)
+			} else {
+				ids = intersect(ids, postings)
+			}
+			if len(ids) == 0 {
+				return nil
+			}
+		}
+	}
+
+	var docs []Doc
+	for _, id := range ids {
+		if q.matches(idx.Docs[id]) {
+			docs = append(docs, idx.Docs[id])
+		}
+	}
+	sort.SliceStable(docs, func(i, j int) bool { return docs[i].Time.After(docs[j].Time) })
+	return docs
+}
+
+// intersect returns the common elements of two ascending lists
+func intersect(a, b []int) []int {
+	var out []int
+	for i, j := 0, 0; i < len(a) && j < len(b); {
+		switch {
+		case a[i] < b[j]:
+			i++
+		case a[i] > b[j]:
+			j++
+		default:
+			out = append(out, a[i])
+			i++
+			j++
+		}
+	}
+	return out
+}
+
+// Open loads the index of a profile's history at path. It is rebuilt from the stored
+// entries only when their files changed since it was written.
+func Open(path string, store *history.Store, profile string) (*Index, error) {
+	files, err := store.Files(profile)
+	if err != nil {
+		return nil, err
+	}
+	// An unreadable index is rebuilt like a missing one
+	var warnings []string
+	idx, err := load(path)
+	if err != nil {
+		warnings = append(warnings, err.Error())
+	}
+	if idx != nil && !idx.stale(files) {
+		return idx, nil
+	}
+
+	entries, err := store.All(profile)
+	if err != nil {
+		return nil, err
+	}
+	idx = Build(entries)
+	idx.Sources = files
+	// The rebuilt index is usable even when it cannot be stored for next time
+	if err := idx.save(path); err != nil {
+		warnings = append(warnings, err.Error())
+	}
+	idx.warnings = warnings
+	return idx, nil
+}
+
+// Warnings returns the problems found by Open that did not prevent searching
+func (idx *Index) Warnings() []string {
+	return idx.warnings
+}
+
+// stale reports whether the index misses, or has outdated, entry files
+func (idx *Index) stale(files map[string]time.Time) bool {
+	if idx.Version != indexVersion || len(files) != len(idx.Sources) {
+		return true
+	}
+	for name, modified := range files {
+		if indexed, ok := idx.Sources[name]; !ok || !indexed.Equal(modified) {
+			return true
+		}
+	}
+	return false
+}
+
+// load reads an index file, returning nil when it does not exist
+func load(path string) (*Index, error) {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read search index: %w", err)
+	}
+
+	var idx Index
+	if err := json.Unmarshal(data, &idx); err != nil {
+		// A corrupt index is rebuilt rather than reported
+		return nil, nil
+	}
+	return &idx, nil
+}
+
+// save writes the index atomically
+func (idx *Index) save(path string) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("failed to create index directory: %w", err)
+	}
+
+	data, err := json.Marshal(idx)
+	if err != nil {
+		return fmt.Errorf("failed to encode search index: %w", err)
+	}
+	// Concurrent searches each write their own temp file
+	tmp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return fmt.Errorf("failed to write search index: %w", err)
+	}
+	_, err = tmp.Write(data)
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err == nil {
+		err = os.Chmod(tmp.Name(), 0644)
+	}
+	if err == nil {
+		err = os.Rename(tmp.Name(), path)
+	}
+	if err != nil {
+		os.Remove(tmp.Name())
+		return fmt.Errorf("failed to write search index: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,175 @@
+package search
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"daily_report/internal/history"
+	"daily_report/pkg/models"
+)
+
+func testEntries(generated time.Time) []history.Entry {
+	loc := time.FixedZone("CST", 8*3600)
+	login := models.Item{
+		Type:     "git",
+		Title:    "fix: login timeout",
+		Time:     time.Date(2026, 10, 14, 23, 30, 0, 0, time.UTC), // Oct 15th in CST
+		Link:     "/src/api/commit/abc123",
+		Metadata: map[string]interface{}{"repo": "api", "body": "Retry the session refresh"},
+	}
+
+	return []history.Entry{
+		{
+			Date: "2026-10-15", Period: models.PeriodDay, GeneratedAt: generated,
+			Data: &models.ReportData{StartTime: time.Date(2026, 10, 15, 0, 0, 0, 0, loc), Items: []models.Item{
+				login,
+				{Type: "meeting", Title: "登录问题复盘", Time: time.Date(2026, 10, 15, 6, 0, 0, 0, time.UTC)},
+			}},
+		},
+		{
+			Date: "2026-10-12", Period: models.PeriodWeek, GeneratedAt: generated,
+			Data: &models.ReportData{StartTime: time.Date(2026, 10, 12, 0, 0, 0, 0, loc), Items: []models.Item{
+				login,
+				{Type: "git", Title: "feat: login page", Time: time.Date(2026, 10, 13, 2, 0, 0, 0, time.UTC), Metadata: map[string]interface{}{"repo": "web"}},
+			}},
+		},
+	}
+}
+
+func TestIndex_Search(t *testing.T) {
+	idx := Build(testEntries(time.Now()))
+	if len(idx.Docs) != 3 {
+		t.Fatalf("Expected items repeated across reports to be indexed once, got %d docs", len(idx.Docs))
+	}
+
+	tests := []struct {
+		query string
+		want  []string
+	}{
+		{"login", []string{"fix: login timeout", "feat: login page"}},
+		{"LOGIN timeout", []string{"fix: login timeout"}},
+		{"session refresh", []string{"fix: login timeout"}},
+		{"login repo:web", []string{"feat: login page"}},
+		{"login before:2026-10-15", []string{"feat: login page"}},
+		{"登录", []string{"登录问题复盘"}},
+		{"登", []string{"登录问题复盘"}},
+		{"盘", []string{"登录问题复盘"}},
+		{"登盘", nil},
+		{"type:meeting", []string{"登录问题复盘"}},
+		{"logout", nil},
+	}
+
+	for _, tt := range tests {
+		q, err := ParseQuery(tt.query)
+		if err != nil {
+			t.Fatalf("ParseQuery(%q) failed: %v", tt.query, err)
+		}
+		var got []string
+		for _, d := range idx.Search(q) {
+			got = append(got, d.Title)
+		}
+		if len(got) != len(tt.want) {
+			t.Errorf("Search(%q) = %v, want %v", tt.query, got, tt.want)
+			continue
+		}
+		for i := range got {
+			if got[i] != tt.want[i] {
+				t.Errorf("Search(%q) = %v, want %v", tt.query, got, tt.want)
+				break
+			}
+		}
+	}
+
+	q, _ := ParseQuery("timeout")
+	if d := idx.Search(q)[0]; d.Date != "2026-10-15" || d.Repo != "api" || d.Link != "/src/api/commit/abc123" {
+		t.Errorf("Unexpected doc: %+v", d)
+	}
+}
+
+func TestOpen_RebuildsWhenStale(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "index.json")
+	store := history.Open(filepath.Join(dir, "history"), 0, 0)
+	generated := time.Date(2026, 10, 15, 18, 0, 0, 0, time.UTC)
+	entries := testEntries(generated)
+	for _, e := range entries {
+		e.Profile = "work"
+		if err := store.Save(e); err != nil {
+			t.Fatalf("Save failed: %v", err)
+		}
+	}
+
+	if _, err := Open(path, store, "work"); err != nil {
+		t.Fatalf("Open failed: %v", err)
+	}
+	if _, err := os.Stat(path); err != nil {
+		t.Fatalf("Expected index to be written: %v", err)
+	}
+
+	// Unchanged entry files are not read again: a file replaced behind the store's
+	// back with its modification time kept goes unnoticed
+	files, _ := store.Files("work")
+	for name, modified := range files {
+		file := filepath.Join(store.Dir(), history.ProfileKey("work"), name)
+		os.WriteFile(file, []byte("{"), 0644)
+		os.Chtimes(file, modified, modified)
+	}
+	idx, err := Open(path, store, "work")
+	if err != nil {
+		t.Fatalf("Expected stored index to be reused, got %v", err)
+	}
+	if len(idx.Docs) != 3 {
+		t.Errorf("Expected stored index to be reused, got %d docs", len(idx.Docs))
+	}
+
+	// Saving entries again rebuilds the index
+	entries[0].Data.Items = entries[0].Data.Items[1:]
+	for _, e := range entries {
+		e.Profile = "work"
+		e.GeneratedAt = generated.Add(time.Hour)
+		store.Save(e)
+	}
+	os.Chtimes(filepath.Join(store.Dir(), history.ProfileKey("work"), "2026-10-15.json"), generated, generated)
+	idx, err = Open(path, store, "work")
+	if err != nil {
+		t.Fatalf("Open failed: %v", err)
+	}
+	if len(idx.Docs) != 3 || !idx.Sources["2026-10-15.json"].Equal(generated) {
+		t.Errorf("Expected index to be rebuilt, got %+v", idx.Sources)
+	}
+
+	// A corrupt index is rebuilt
+	os.WriteFile(path, []byte("{"), 0644)
+	if idx, err = Open(path, store, "work"); err != nil || len(idx.Docs) != 3 {
+		t.Errorf("Expected corrupt index to be rebuilt, got %v", err)
+	}
+}
+
+func TestOpen_SaveFailureIsWarning(t *testing.T) {
+	dir := t.TempDir()
+	store := history.Open(filepath.Join(dir, "history"), 0, 0)
+	for _, e := range testEntries(time.Date(2026, 10, 15, 18, 0, 0, 0, time.UTC)) {
+		e.Profile = "work"
+		if err := store.Save(e); err != nil {
+			t.Fatalf("Save failed: %v", err)
+		}
+	}
+
+	// A file where the index directory should be makes reading and saving fail
+	blocked := filepath.Join(dir, "blocked")
+	os.WriteFile(blocked, nil, 0644)
+
+	idx, err := Open(filepath.Join(blocked, "index.json"), store, "work")
+	if err != nil {
+		t.Fatalf("Expected a save failure not to fail Open, got %v", err)
+	}
+	if len(idx.Docs) != 3 {
+		t.Errorf("Expected the rebuilt index to be returned, got %d docs", len(idx.Docs))
+	}
+	if warnings := idx.Warnings(); len(warnings) != 2 || !strings.HasPrefix(warnings[1], "failed to create index directory") {
+		t.Errorf("Expected read and write warnings, got %v", warnings)
+	}
+}
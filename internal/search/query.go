@@ -0,0 +1,73 @@
+package search
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// dateLayout is the layout of before: and after: filters
+const dateLayout = "2006-01-02"
+
+// Query is a parsed search query: text terms that must all match, plus field filters
+type Query struct {
+	Terms  []string
+	Repo   string    // repo: substring of the repository name
+	Type   string    // type: item type, e.g. git or jira
+	Before time.Time // before: items on earlier dates only
+	After  time.Time // after: items on later dates only
+}
+
+// ParseQuery parses e.g. `login timeout repo:api type:git before:2026-10-01`
+func ParseQuery(input string) (Query, error) {
+	var q Query
+	var text []string
+
+	for _, field := range strings.Fields(input) {
+		key, value, ok := strings.Cut(field, ":")
+		if !ok || value == "" {
+			text = append(text, field)
+			continue
+		}
+
+		switch strings.ToLower(key) {
+		case "repo":
+			q.Repo = strings.ToLower(value)
+		case "type":
+			q.Type = strings.ToLower(value)
+		case "before", "after":
+			date, err := time.Parse(dateLayout, value)
+			if err != nil {
+				return Query{}, fmt.Errorf("invalid %s filter %q: use YYYY-MM-DD", key, value)
+			}
+			if strings.ToLower(key) == "before" {
+				q.Before = date
+			} else {
+				q.After = date
+			}
+		default:
+			// Not a filter, e.g. "fix:" in a commit subject
+			text = append(text, field)
+		}
+	}
+
+	q.Terms = tokenize(strings.Join(text, " "))
+	return q, nil
+}
+
+// matches reports whether a document passes the field filters
+func (q Query) matches(d Doc) bool {
+	if q.Type != "" && strings.ToLower(d.Type) != q.Type {
+		return false
+	}
+	if q.Repo != "" && !strings.Contains(strings.ToLower(d.Repo), q.Repo) {
+		return false
+	}
+	if !q.Before.IsZero() && d.Date >= q.Before.Format(dateLayout) {
+		return false
+	}
+	if !q.After.IsZero() && d.Date <= q.After.Format(dateLayout) {
+		return false
+	}
+	return true
+}
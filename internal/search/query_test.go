@@ -0,0 +1,57 @@
+package search
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestParseQuery(t *testing.T) {
+	q, err := ParseQuery("Login timeout repo:API type:git before:2026-10-01 after:2026-09-01 fix:")
+	if err != nil {
+		t.Fatalf("ParseQuery failed: %v", err)
+	}
+
+	want := Query{
+		Terms:  []string{"login", "timeout", "fix"},
+		Repo:   "api",
+		Type:   "git",
+		Before: time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC),
+		After:  time.Date(2026, 9, 1, 0, 0, 0, 0, time.UTC),
+	}
+	if !reflect.DeepEqual(q, want) {
+		t.Errorf("ParseQuery = %+v, want %+v", q, want)
+	}
+
+	if _, err := ParseQuery("before:yesterday"); err == nil {
+		t.Error("Expected error for invalid before: date")
+	}
+}
+
+func TestQuery_Matches(t *testing.T) {
+	doc := Doc{Date: "2026-10-15", Type: "git", Repo: "api-server"}
+
+	tests := []struct {
+		input string
+		want  bool
+	}{
+		{"repo:api", true},
+		{"repo:web", false},
+		{"type:GIT", true},
+		{"type:jira", false},
+		{"before:2026-10-16", true},
+		{"before:2026-10-15", false},
+		{"after:2026-10-14", true},
+		{"after:2026-10-15", false},
+	}
+
+	for _, tt := range tests {
+		q, err := ParseQuery(tt.input)
+		if err != nil {
+			t.Fatalf("ParseQuery(%q) failed: %v", tt.input, err)
+		}
+		if got := q.matches(doc); got != tt.want {
+			t.Errorf("%q matches = %v, want %v", tt.input, got, tt.want)
+		}
+	}
+}
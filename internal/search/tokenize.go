@@ -0,0 +1,63 @@
+package search
+
+import (
+	"strings"
+	"unicode"
+)
+
+// tokenize splits text into lower-case index terms. Latin words and numbers
+// are whole terms; runs of Han characters become overlapping bigrams so that
+// Chinese queries match without a dictionary.
+func tokenize(text string) []string {
+	var terms []string
+	var word []rune
+	var han []rune
+
+	flushWord := func() {
+		if len(word) > 0 {
+			terms = append(terms, strings.ToLower(string(word)))
+			word = word[:0]
+		}
+	}
+	flushHan := func() {
+		switch {
+		case len(han) == 1:
+			terms = append(terms, string(han))
+		case len(han) > 1:
+			for i := 0; i+1 < len(han); i++ {
+				terms = append(terms, string(han[i:i+2]))
+			}
+		}
+		han = han[:0]
+	}
+
+	for _, r := range text {
+		switch {
+		case unicode.Is(unicode.Han, r):
+			flushWord()
+			han = append(han, r)
+		case unicode.IsLetter(r) || unicode.IsDigit(r):
+			flushHan()
+			word = append(word, r)
+		default:
+			flushWord()
+			flushHan()
+		}
+	}
+	flushWord()
+	flushHan()
+
+	return terms
+}
+
+// indexTerms returns the terms a document is indexed under: its query terms plus each Han
+// character on its own, so that a one-character query matches inside longer words
+func indexTerms(text string) []string {
+	terms := tokenize(text)
+	for _, r := range text {
+		if unicode.Is(unicode.Han, r) {
+			terms = append(terms, string(r))
+		}
+	}
+	return terms
+}
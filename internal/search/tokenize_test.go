@@ -0,0 +1,33 @@
+package search
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestTokenize(t *testing.T) {
+	tests := []struct {
+		input string
+		want  []string
+	}{
+		{"fix: Login timeout (#12)", []string{"fix", "login", "timeout", "12"}},
+		{"修复登录超时", []string{"修复", "复登", "登录", "录超", "超时"}},
+		{"支持OAuth登录", []string{"支持", "oauth", "登录"}},
+		{"改 bug", []string{"改", "bug"}},
+		{"", nil},
+	}
+
+	for _, tt := range tests {
+		if got := tokenize(tt.input); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("tokenize(%q) = %v, want %v", tt.input, got, tt.want)
+		}
+	}
+}
+
+func TestIndexTerms(t *testing.T) {
+	got := indexTerms("修复登录")
+	want := []string{"修复", "复登", "登录", "修", "复", "登", "录"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("indexTerms = %v, want %v", got, want)
+	}
+}
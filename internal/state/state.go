@@ -0,0 +1,138 @@
+package state
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// stateFile is the name of the state file inside the state directory
+const stateFile = "state.json"
+
+// Store persists small per-profile facts between runs, such as the end of the last report
+type Store struct {
+	dir string
+}
+
+// Profile is the state recorded for one config or profile
+type Profile struct {
+	LastReportEnd time.Time `json:"last_report_end"`
+}
+
+// fileData is the on-disk representation of the state file
+type fileData struct {
+	Profiles map[string]Profile `json:"profiles"`
+}
+
+// DefaultDir returns <user config dir>/daily_report
+func DefaultDir() (string, error) {
+	base, err := os.UserConfigDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to locate user config directory: %w", err)
+	}
+	return filepath.Join(base, "daily_report"), nil
+}
+
+// Open returns a store rooted at dir; an empty dir uses DefaultDir
+func Open(dir string) (*Store, error) {
+	if dir == "" {
+		var err error
+		dir, err = DefaultDir()
+		if err != nil {
+			return nil, err
+		}
+	}
+	return &Store{dir: dir}, nil
+}
+
+// Dir returns the state directory
+func (s *Store) Dir() string {
+	return s.dir
+}
+
+// LastReportEnd returns the end of the last successful report of profile, if any
+func (s *Store) LastReportEnd(profile string) (time.Time, bool, error) {
+	data, err := s.read()
+	if err != nil {
+		return time.Time{}, false, err
+	}
+	p, ok := data.Profiles[profile]
+	if !ok || p.LastReportEnd.IsZero() {
+		return time.Time{}, false, nil
+	}
+	return p.LastReportEnd, true, nil
+}
+
+// SetLastReportEnd records the end of a successful report; earlier ends never replace later ones
+func (s *Store) SetLastReportEnd(profile string, end time.Time) error {
+	data, err := s.read()
+	if err != nil {
+		return err
+	}
+
+	p := data.Profiles[profile]
+	if !end.After(p.LastReportEnd) {
+		return nil
+	}
+	p.LastReportEnd = end
+	data.Profiles[profile] = p
+
+	return s.write(data)
+}
+
+// read loads the state file, returning empty state when it does not exist yet
+func (s *Store) read() (*fileData, error) {
+	data := &fileData{Profiles: make(map[string]Profile)}
+
+	raw, err := os.ReadFile(filepath.Join(s.dir, stateFile))
+	if errors.Is(err, os.ErrNotExist) {
+		return data, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read state file: %w", err)
+	}
+	if err := json.Unmarshal(raw, data); err != nil {
+		return nil, fmt.Errorf("failed to parse state file: %w", err)
+	}
+	if data.Profiles == nil {
+		data.Profiles = make(map[string]Profile)
+	}
+	return data, nil
+}
+
+// write stores the state file atomically
+func (s *Store) write(data *fileData) error {
+	if err := os.MkdirAll(s.dir, 0755); err != nil {
+		return fmt.Errorf("failed to create state directory: %w", err)
+	}
+
+	raw, err := json.MarshalIndent(data, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode state: %w", err)
+	}
+
+	// Write to a unique temp file first so a crash never leaves a partial state file
+	// and concurrent runs do not write the same temp file
+	tmp, err := os.CreateTemp(s.dir, stateFile+".*.tmp")
+	if err != nil {
+		return fmt.Errorf("failed to write state file: %w", err)
+	}
+	_, err = tmp.Write(raw)
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err == nil {
+		err = os.Chmod(tmp.Name(), 0644)
+	}
+	if err == nil {
+		err = os.Rename(tmp.Name(), filepath.Join(s.dir, stateFile))
+	}
+	if err != nil {
+		os.Remove(tmp.Name())
+		return fmt.Errorf("failed to write state file: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,73 @@
+package state
+
+import (
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestStore_LastReportEnd(t *testing.T) {
+	store, err := Open(t.TempDir())
+	if err != nil {
+		t.Fatalf("Open failed: %v", err)
+	}
+
+	if _, ok, err := store.LastReportEnd("work"); err != nil || ok {
+		t.Fatalf("Expected no record on empty store, got ok=%v err=%v", ok, err)
+	}
+
+	end := time.Date(2026, 10, 19, 18, 0, 0, 0, time.UTC)
+	if err := store.SetLastReportEnd("work", end); err != nil {
+		t.Fatalf("SetLastReportEnd failed: %v", err)
+	}
+	// An older report, e.g. regenerating last week, must not move the mark back
+	if err := store.SetLastReportEnd("work", end.Add(-48*time.Hour)); err != nil {
+		t.Fatalf("SetLastReportEnd failed: %v", err)
+	}
+
+	got, ok, err := store.LastReportEnd("work")
+	if err != nil || !ok || !got.Equal(end) {
+		t.Errorf("LastReportEnd = %v, %v, %v; want %v", got, ok, err, end)
+	}
+	if _, ok, _ := store.LastReportEnd("home"); ok {
+		t.Error("Profiles should be tracked separately")
+	}
+}
+
+func TestStore_CorruptFile(t *testing.T) {
+	dir := t.TempDir()
+	os.WriteFile(filepath.Join(dir, stateFile), []byte("{"), 0644)
+
+	store, _ := Open(dir)
+	if _, _, err := store.LastReportEnd("work"); err == nil {
+		t.Error("Expected error for corrupt state file")
+	}
+}
+
+func TestStore_ConcurrentWrites(t *testing.T) {
+	dir := t.TempDir()
+	store, _ := Open(dir)
+	end := time.Date(2026, 10, 19, 18, 0, 0, 0, time.UTC)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			if err := store.SetLastReportEnd("work", end.Add(time.Duration(i)*time.Minute)); err != nil {
+				t.Errorf("SetLastReportEnd failed: %v", err)
+			}
+		}(i)
+	}
+	wg.Wait()
+
+	if _, ok, err := store.LastReportEnd("work"); err != nil || !ok {
+		t.Errorf("Expected a readable state file, got %v", err)
+	}
+	files, _ := os.ReadDir(dir)
+	if len(files) != 1 || files[0].Name() != stateFile {
+		t.Errorf("Expected only the state file to remain, got %v", files)
+	}
+}
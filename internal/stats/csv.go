@@ -0,0 +1,46 @@
+package stats
+
+import (
+	"encoding/csv"
+	"io"
+	"strconv"
+)
+
+// WriteCSV writes one row per bucket
+func (s *Series) WriteCSV(w io.Writer) error {
+	cw := csv.NewWriter(w)
+	cw.Write([]string{"start", "commits", "additions", "deletions", "meeting_hours", "jira_transitions", "active_repos"})
+	for _, p := range s.Points {
+		cw.Write([]string{
+			p.Start.Format(dateLayout),
+			strconv.Itoa(p.Commits),
+			strconv.Itoa(p.Additions),
+			strconv.Itoa(p.Deletions),
+			strconv.FormatFloat(p.MeetingHours, 'f', 2, 64),
+			strconv.Itoa(p.JiraTransitions),
+			strconv.Itoa(p.ActiveRepos),
+		})
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+// WriteRepoCSV writes one row per bucket and repository
+func (s *Series) WriteRepoCSV(w io.Writer) error {
+	cw := csv.NewWriter(w)
+	cw.Write([]string{"start", "repo", "commits", "additions", "deletions"})
+	for i := range s.Points {
+		for _, r := range s.Repos {
+			p := r.Points[i]
+			cw.Write([]string{
+				p.Start.Format(dateLayout),
+				r.Repo,
+				strconv.Itoa(p.Commits),
+				strconv.Itoa(p.Additions),
+				strconv.Itoa(p.Deletions),
+			})
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
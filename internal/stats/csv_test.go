@@ -0,0 +1,40 @@
+package stats
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"daily_report/pkg/models"
+)
+
+func TestSeries_WriteCSV(t *testing.T) {
+	s, err := Compute(testItems(), at(12, 4), at(14, 4).Add(-time.Nanosecond), 4*time.Hour, models.PeriodDay)
+	if err != nil {
+		t.Fatalf("Compute failed: %v", err)
+	}
+
+	var sb strings.Builder
+	if err := s.WriteCSV(&sb); err != nil {
+		t.Fatalf("WriteCSV failed: %v", err)
+	}
+	want := "start,commits,additions,deletions,meeting_hours,jira_transitions,active_repos\n" +
+		"2026-10-12,3,22,3,0.50,0,2\n" +
+		"2026-10-13,0,0,0,1.50,2,0\n"
+	if sb.String() != want {
+		t.Errorf("WriteCSV =\n%s\nwant\n%s", sb.String(), want)
+	}
+
+	sb.Reset()
+	if err := s.WriteRepoCSV(&sb); err != nil {
+		t.Fatalf("WriteRepoCSV failed: %v", err)
+	}
+	want = "start,repo,commits,additions,deletions\n" +
+		"2026-10-12,api,2,15,3\n" +
+		"2026-10-12,web,1,7,0\n" +
+		"2026-10-13,api,0,0,0\n" +
+		"2026-10-13,web,0,0,0\n"
+	if sb.String() != want {
+		t.Errorf("WriteRepoCSV =\n%s\nwant\n%s", sb.String(), want)
+	}
+}
@@ -0,0 +1,106 @@
+package stats
+
+import (
+	"fmt"
+	"math"
+	"strings"
+	"text/tabwriter"
+
+	"daily_report/pkg/models"
+)
+
+// sparkLevels are the bar heights of a sparkline, lowest first
+var sparkLevels = []rune("▁▂▃▄▅▆▇█")
+
+// Sparkline draws one bar per value scaled to the largest value; zero is the lowest bar
+func Sparkline(values []float64) string {
+	max := 0.0
+	for _, v := range values {
+		max = math.Max(max, v)
+	}
+
+	var sb strings.Builder
+	for _, v := range values {
+		level := 0
+		if max > 0 && v > 0 {
+			level = int(math.Round(v / max * float64(len(sparkLevels)-1)))
+		}
+		sb.WriteRune(sparkLevels[level])
+	}
+	return sb.String()
+}
+
+// Format renders the series as a sparkline summary, a table per bucket and a table per repository
+func (s *Series) Format() string {
+	if len(s.Points) == 0 {
+		return ""
+	}
+
+	var sb strings.Builder
+	total := s.Total()
+	n := float64(len(s.Points))
+
+	metric := func(get func(Point) float64) string {
+		values := make([]float64, len(s.Points))
+		for i, p := range s.Points {
+			values[i] = get(p)
+		}
+		return Sparkline(values)
+	}
+
+	w := tabwriter.NewWriter(&sb, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(w, "METRIC\tTREND\tTOTAL\tPER %s\n", strings.ToUpper(s.Bucket))
+	fmt.Fprintf(w, "commits\t%s\t%d\t%.1f\n", metric(func(p Point) float64 { return float64(p.Commits) }), total.Commits, float64(total.Commits)/n)
+	fmt.Fprintf(w, "lines added\t%s\t%d\t%.0f\n", metric(func(p Point) float64 { return float64(p.Additions) }), total.Additions, float64(total.Additions)/n)
+	fmt.Fprintf(w, "lines deleted\t%s\t%d\t%.0f\n", metric(func(p Point) float64 { return float64(p.Deletions) }), total.Deletions, float64(total.Deletions)/n)
+	fmt.Fprintf(w, "meeting hours\t%s\t%.1f\t%.1f\n", metric(func(p Point) float64 { return p.MeetingHours }), total.MeetingHours, total.MeetingHours/n)
+	fmt.Fprintf(w, "jira transitions\t%s\t%d\t%.1f\n", metric(func(p Point) float64 { return float64(p.JiraTransitions) }), total.JiraTransitions, float64(total.JiraTransitions)/n)
+	fmt.Fprintf(w, "active repos\t%s\t%d\t%.1f\n", metric(func(p Point) float64 { return float64(p.ActiveRepos) }), total.ActiveRepos, s.averageActiveRepos())
+	w.Flush()
+
+	sb.WriteString("\n")
+	w = tabwriter.NewWriter(&sb, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(w, "%s\tCOMMITS\t+LINES\t-LINES\tMEETING H\tJIRA\tREPOS\n", strings.ToUpper(s.Bucket))
+	for _, p := range s.Points {
+		fmt.Fprintf(w, "%s\t%d\t%d\t%d\t%.1f\t%d\t%d\n", s.label(p),
+			p.Commits, p.Additions, p.Deletions, p.MeetingHours, p.JiraTransitions, p.ActiveRepos)
+	}
+	w.Flush()
+
+	if len(s.Repos) == 0 {
+		return sb.String()
+	}
+	sb.WriteString("\n")
+	w = tabwriter.NewWriter(&sb, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "REPO\tCOMMITS\t+LINES\t-LINES\tACTIVE DAYS\tTREND")
+	for _, r := range s.Repos {
+		values := make([]float64, len(r.Points))
+		for i, p := range r.Points {
+			values[i] = float64(p.Commits)
+		}
+		fmt.Fprintf(w, "%s\t%d\t%d\t%d\t%d\t%s\n", r.Repo, r.Commits, r.Additions, r.Deletions, r.ActiveDays, Sparkline(values))
+	}
+	w.Flush()
+
+	return sb.String()
+}
+
+// label names a bucket in the table
+func (s *Series) label(p Point) string {
+	switch s.Bucket {
+	case models.PeriodWeek:
+		return p.Start.Format("2006-01-02")
+	case models.PeriodMonth:
+		return p.Start.Format("2006-01")
+	}
+	return p.Start.Format("2006-01-02 Mon")
+}
+
+// averageActiveRepos is the mean number of repositories with commits per bucket
+func (s *Series) averageActiveRepos() float64 {
+	sum := 0
+	for _, p := range s.Points {
+		sum += p.ActiveRepos
+	}
+	return float64(sum) / float64(len(s.Points))
+}
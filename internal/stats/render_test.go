@@ -0,0 +1,45 @@
+package stats
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"daily_report/pkg/models"
+)
+
+func TestSparkline(t *testing.T) {
+	tests := []struct {
+		values []float64
+		want   string
+	}{
+		{[]float64{0, 1, 2, 4, 8}, "▁▂▃▅█"},
+		{[]float64{0, 0}, "▁▁"},
+		{[]float64{3}, "█"},
+		{nil, ""},
+	}
+	for _, tt := range tests {
+		if got := Sparkline(tt.values); got != tt.want {
+			t.Errorf("Sparkline(%v) = %q, want %q", tt.values, got, tt.want)
+		}
+	}
+}
+
+func TestSeries_Format(t *testing.T) {
+	s, err := Compute(testItems(), at(12, 4), at(15, 4).Add(-time.Nanosecond), 4*time.Hour, models.PeriodDay)
+	if err != nil {
+		t.Fatalf("Compute failed: %v", err)
+	}
+
+	out := s.Format()
+	for _, want := range []string{
+		"commits           █▁▃    4      1.3",
+		"meeting hours     ▃█▁    2.0    0.7",
+		"2026-10-13 Tue  0        0       0       1.5        2     0",
+		"api   2        15      3       1            █▁▁",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("Expected output to contain %q, got:\n%s", want, out)
+		}
+	}
+}
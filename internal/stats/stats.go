@@ -0,0 +1,196 @@
+package stats
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"daily_report/internal/timeutil"
+	"daily_report/internal/worklog"
+	"daily_report/pkg/models"
+)
+
+// dateLayout is the layout of bucket and day keys
+const dateLayout = "2006-01-02"
+
+// Point holds the activity of one day, week or month
+type Point struct {
+	Start           time.Time // First calendar day of the bucket: the workday, the Monday of the week or the 1st of the month
+	Commits         int
+	Additions       int
+	Deletions       int
+	MeetingHours    float64
+	JiraTransitions int // Status changes of Jira issues
+	ActiveRepos     int // Repositories with commits
+}
+
+// RepoStats is the commit activity of one repository
+type RepoStats struct {
+	Repo       string
+	Commits    int
+	Additions  int
+	Deletions  int
+	ActiveDays int
+	Points     []Point // Per bucket, with only the commit and line counts set
+}
+
+// Series is the activity over a range, one point per bucket including empty ones
+type Series struct {
+	Bucket string // day, week or month
+	Points []Point
+	Repos  []RepoStats // Most commits first
+}
+
+// Compute buckets the items between start and end by workday, ISO week or month. Buckets
+// are in the location of start and days begin at dayStart.
+func Compute(items []models.Item, start, end time.Time, dayStart time.Duration, bucket string) (*Series, error) {
+	if bucket != models.PeriodDay && bucket != models.PeriodWeek && bucket != models.PeriodMonth {
+		return nil, fmt.Errorf("unsupported bucket %q: use day, week or month", bucket)
+	}
+	loc := start.Location()
+	bucketOf := func(t time.Time) time.Time {
+		return bucketStart(timeutil.WorkdayDate(t.In(loc), dayStart), bucket)
+	}
+
+	s := &Series{Bucket: bucket}
+	index := make(map[string]int)
+	last := bucketOf(end)
+	for b := bucketOf(start); !b.After(last); b = nextBucket(b, bucket) {
+		index[b.Format(dateLayout)] = len(s.Points)
+		s.Points = append(s.Points, Point{Start: b})
+	}
+
+	repos := make(map[string]*RepoStats)
+	repoBuckets := make(map[int]map[string]bool)
+	repoDays := make(map[string]map[string]bool)
+	lastStatus := make(map[string]string)
+
+	// Jira status changes are found by comparing each update with the previous one
+	sorted := append([]models.Item(nil), items...)
+	sort.SliceStable(sorted, func(i, j int) bool { return sorted[i].Time.Before(sorted[j].Time) })
+
+	for _, item := range sorted {
+		if item.Time.Before(start) || item.Time.After(end) {
+			continue
+		}
+		i, ok := index[bucketOf(item.Time).Format(dateLayout)]
+		if !ok {
+			continue
+		}
+		p := &s.Points[i]
+		day := timeutil.WorkdayDate(item.Time.In(loc), dayStart).Format(dateLayout)
+
+		switch item.Type {
+		case "git":
+			additions, deletions := intMeta(item, "additions"), intMeta(item, "deletions")
+			p.Commits++
+			p.Additions += additions
+			p.Deletions += deletions
+
+			repo, _ := item.Metadata["repo"].(string)
+			if repo == "" {
+				continue
+			}
+			r := repos[repo]
+			if r == nil {
+				r = &RepoStats{Repo: repo, Points: make([]Point, len(s.Points))}
+				for j := range r.Points {
+					r.Points[j].Start = s.Points[j].Start
+				}
+				repos[repo] = r
+				repoDays[repo] = make(map[string]bool)
+			}
+			r.Commits++
+			r.Additions += additions
+			r.Deletions += deletions
+			r.Points[i].Commits++
+			r.Points[i].Additions += additions
+			r.Points[i].Deletions += deletions
+			repoDays[repo][day] = true
+			if repoBuckets[i] == nil {
+				repoBuckets[i] = make(map[string]bool)
+			}
+			repoBuckets[i][repo] = true
+		case "meeting":
+			p.MeetingHours += worklog.MeetingDuration(item).Hours()
+		case "jira":
+			status, _ := item.Metadata["status"].(string)
+			if status == "" {
+				continue
+			}
+			issue := item.Link
+			if issue == "" {
+				issue = item.Title
+			}
+			// Updates that keep the issue in the same status are not transitions
+			if lastStatus[issue] != status {
+				lastStatus[issue] = status
+				p.JiraTransitions++
+			}
+		}
+	}
+
+	for i, active := range repoBuckets {
+		s.Points[i].ActiveRepos = len(active)
+	}
+	for repo, r := range repos {
+		r.ActiveDays = len(repoDays[repo])
+		s.Repos = append(s.Repos, *r)
+	}
+	sort.Slice(s.Repos, func(i, j int) bool {
+		if s.Repos[i].Commits != s.Repos[j].Commits {
+			return s.Repos[i].Commits > s.Repos[j].Commits
+		}
+		return s.Repos[i].Repo < s.Repos[j].Repo
+	})
+
+	return s, nil
+}
+
+// Total sums the points; ActiveRepos is the number of distinct repositories
+func (s *Series) Total() Point {
+	var t Point
+	for _, p := range s.Points {
+		t.Commits += p.Commits
+		t.Additions += p.Additions
+		t.Deletions += p.Deletions
+		t.MeetingHours += p.MeetingHours
+		t.JiraTransitions += p.JiraTransitions
+	}
+	t.ActiveRepos = len(s.Repos)
+	return t
+}
+
+// bucketStart returns the first calendar day of the bucket containing day
+func bucketStart(day time.Time, bucket string) time.Time {
+	switch bucket {
+	case models.PeriodWeek:
+		offset := (int(day.Weekday()) + 6) % 7 // Days since Monday
+		return day.AddDate(0, 0, -offset)
+	case models.PeriodMonth:
+		return time.Date(day.Year(), day.Month(), 1, 0, 0, 0, 0, day.Location())
+	}
+	return day
+}
+
+// nextBucket returns the start of the bucket after b
+func nextBucket(b time.Time, bucket string) time.Time {
+	switch bucket {
+	case models.PeriodWeek:
+		return b.AddDate(0, 0, 7)
+	case models.PeriodMonth:
+		return b.AddDate(0, 1, 0)
+	}
+	return b.AddDate(0, 0, 1)
+}
+
+// intMeta reads a numeric metadata field; data loaded from JSON holds float64
+func intMeta(item models.Item, key string) int {
+	switch v := item.Metadata[key].(type) {
+	case int:
+		return v
+	case float64:
+		return int(v)
+	}
+	return 0
+}
@@ -0,0 +1,129 @@
+package stats
+
+import (
+	"testing"
+	"time"
+
+	"daily_report/pkg/models"
+)
+
+var cst = time.FixedZone("CST", 8*3600)
+
+func at(day, hour int) time.Time {
+	return time.Date(2026, 10, day, hour, 0, 0, 0, cst)
+}
+
+func commit(repo string, t time.Time, additions, deletions interface{}) models.Item {
+	return models.Item{Type: "git", Title: "commit", Time: t, Metadata: map[string]interface{}{
+		"repo": repo, "additions": additions, "deletions": deletions,
+	}}
+}
+
+func testItems() []models.Item {
+	return []models.Item{
+		commit("api", at(12, 10), 10, 2),
+		commit("api", at(12, 15), 5.0, 1.0), // Loaded from JSON
+		commit("web", at(13, 2), 7, 0),      // Before the 04:00 day start: Oct 12th
+		commit("web", at(14, 11), 1, 1),
+		{Type: "meeting", Title: "Standup", Time: at(12, 9), Metadata: map[string]interface{}{"duration": 30}},
+		{Type: "meeting", Title: "Review", Time: at(13, 14), Metadata: map[string]interface{}{"duration": "1h30m"}},
+		{Type: "jira", Title: "PROJ-1", Link: "j/PROJ-1", Time: at(13, 10), Metadata: map[string]interface{}{"status": "In Progress"}},
+		{Type: "jira", Title: "PROJ-1", Link: "j/PROJ-1", Time: at(13, 11), Metadata: map[string]interface{}{"status": "In Progress"}},
+		{Type: "jira", Title: "PROJ-1", Link: "j/PROJ-1", Time: at(13, 17), Metadata: map[string]interface{}{"status": "Done"}},
+		{Type: "jira", Title: "PROJ-2 comment", Time: at(13, 12)},
+		commit("api", at(20, 10), 99, 99), // Outside the range
+	}
+}
+
+func TestCompute_Days(t *testing.T) {
+	s, err := Compute(testItems(), at(12, 4), at(15, 4).Add(-time.Nanosecond), 4*time.Hour, models.PeriodDay)
+	if err != nil {
+		t.Fatalf("Compute failed: %v", err)
+	}
+	if len(s.Points) != 3 {
+		t.Fatalf("Expected 3 days, got %d", len(s.Points))
+	}
+
+	want := []Point{
+		{Commits: 3, Additions: 22, Deletions: 3, MeetingHours: 0.5, ActiveRepos: 2},
+		{MeetingHours: 1.5, JiraTransitions: 2},
+		{Commits: 1, Additions: 1, Deletions: 1, ActiveRepos: 1},
+	}
+	for i, p := range s.Points {
+		if day := time.Date(2026, 10, 12+i, 0, 0, 0, 0, cst); !p.Start.Equal(day) {
+			t.Errorf("Point %d starts %v, want %v", i, p.Start, day)
+		}
+		want[i].Start = p.Start
+		if p != want[i] {
+			t.Errorf("Point %d = %+v, want %+v", i, p, want[i])
+		}
+	}
+
+	if len(s.Repos) != 2 || s.Repos[0].Repo != "api" || s.Repos[0].Commits != 2 || s.Repos[0].Additions != 15 {
+		t.Fatalf("Unexpected repos: %+v", s.Repos)
+	}
+	if web := s.Repos[1]; web.ActiveDays != 2 || web.Points[0].Commits != 1 || web.Points[2].Commits != 1 {
+		t.Errorf("Unexpected web stats: %+v", web)
+	}
+
+	total := s.Total()
+	if total.Commits != 4 || total.MeetingHours != 2 || total.JiraTransitions != 2 || total.ActiveRepos != 2 {
+		t.Errorf("Unexpected total: %+v", total)
+	}
+}
+
+func TestCompute_Buckets(t *testing.T) {
+	start := time.Date(2026, 10, 1, 0, 0, 0, 0, cst)
+	end := time.Date(2026, 11, 30, 23, 0, 0, 0, cst)
+
+	tests := []struct {
+		bucket string
+		points int
+		first  string
+	}{
+		{models.PeriodDay, 61, "2026-10-01"},
+		{models.PeriodWeek, 10, "2026-09-28"},
+		{models.PeriodMonth, 2, "2026-10-01"},
+	}
+	for _, tt := range tests {
+		s, err := Compute(testItems(), start, end, 0, tt.bucket)
+		if err != nil {
+			t.Fatalf("Compute(%s) failed: %v", tt.bucket, err)
+		}
+		if len(s.Points) != tt.points || s.Points[0].Start.Format(dateLayout) != tt.first {
+			t.Errorf("Compute(%s): %d points from %s, want %d from %s", tt.bucket, len(s.Points), s.Points[0].Start.Format(dateLayout), tt.points, tt.first)
+		}
+		if total := s.Total(); total.Commits != 5 {
+			t.Errorf("Compute(%s): %d commits, want 5", tt.bucket, total.Commits)
+		}
+	}
+
+	if _, err := Compute(nil, start, end, 0, "year"); err == nil {
+		t.Error("Expected error for unsupported bucket")
+	}
+}
+
+func TestCompute_JiraTransitions(t *testing.T) {
+	jira := func(t time.Time, status string) models.Item {
+		return models.Item{Type: "jira", Title: "PROJ-1", Link: "j/PROJ-1", Time: t, Metadata: map[string]interface{}{"status": status}}
+	}
+	// Out of order on purpose; the update on the 14th keeps the status of the 13th
+	items := []models.Item{
+		jira(at(15, 10), "Done"),
+		jira(at(13, 10), "To Do"),
+		jira(at(14, 10), "In Progress"),
+		jira(at(13, 16), "In Progress"),
+	}
+
+	s, err := Compute(items, at(13, 0), at(15, 23), 0, models.PeriodDay)
+	if err != nil {
+		t.Fatalf("Compute failed: %v", err)
+	}
+	var got []int
+	for _, p := range s.Points {
+		got = append(got, p.JiraTransitions)
+	}
+	if len(got) != 3 || got[0] != 2 || got[1] != 0 || got[2] != 1 {
+		t.Errorf("Expected transitions [2 0 1], got %v", got)
+	}
+}
@@ -0,0 +1,320 @@
+package timeutil
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Range is a time range with inclusive bounds
+type Range struct {
+	Start time.Time
+	End   time.Time
+}
+
+// WorkdayCalendar decides which dates are working days
+type WorkdayCalendar interface {
+	IsWorkday(date time.Time) bool
+}
+
+// RangeParser turns date expressions into time ranges relative to a clock
+type RangeParser struct {
+	Location *time.Location
+	Now      func() time.Time // Defaults to time.Now
+	DayStart time.Duration    // Workday boundary after midnight, e.g. 4h
+	Calendar WorkdayCalendar  // Defaults to Monday to Friday
+}
+
+// NewRangeParser returns a parser for the given timezone using the wall clock
+func NewRangeParser(timezone string) (*RangeParser, error) {
+	loc, err := time.LoadLocation(timezone)
+	if err != nil {
+		return nil, err
+	}
+	return &RangeParser{Location: loc, Now: time.Now}, nil
+}
+
+var (
+	// daysAgoPattern matches "-3d"
+	daysAgoPattern = regexp.MustCompile(`^-(\d+)d$`)
+	// isoWeekPattern matches "2026-W42"
+	isoWeekPattern = regexp.MustCompile(`^(\d{4})-w(\d{1,2})$`)
+	// monthPattern matches "2026-10"
+	monthPattern = regexp.MustCompile(`^(\d{4})-(\d{1,2})$`)
+)
+
+// weekdays maps lower-case English weekday names to time.Weekday
+var weekdays = map[string]time.Weekday{
+	"sunday":    time.Sunday,
+	"monday":    time.Monday,
+	"tuesday":   time.Tuesday,
+	"wednesday": time.Wednesday,
+	"thursday":  time.Thursday,
+	"friday":    time.Friday,
+	"saturday":  time.Saturday,
+}
+
+// Parse resolves an expression or a "START,END" range of expressions.
+// Expressions: today/今天, yesterday/昨天, 前天, -Nd (N days ago),
+// last-workday/最近工作日 (today if a workday), prev-workday/上个工作日,
+// this-week/本周, last-week/上周, this-month/本月, last-month/上月,
+// last-monday … last-sunday, 2026-W42 (ISO week), 2026-10 and 2026-10-19.
+// The range starts at the beginning of START and ends at the end of END;
+// an empty END means now.
+func (p *RangeParser) Parse(input string) (time.Time, time.Time, error) {
+	input = strings.TrimSpace(input)
+	if !strings.Contains(input, ",") {
+		return p.parseExpr(input)
+	}
+
+	parts := strings.SplitN(input, ",", 2)
+	start, _, err := p.parseExpr(parts[0])
+	if err != nil {
+		return time.Time{}, time.Time{}, err
+	}
+
+	end := p.now()
+	if strings.TrimSpace(parts[1]) != "" {
+		_, end, err = p.parseExpr(parts[1])
+		if err != nil {
+			return time.Time{}, time.Time{}, err
+		}
+	}
+
+	if !start.Before(end) {
+		return time.Time{}, time.Time{}, fmt.Errorf("invalid range %q: start must be before end", input)
+	}
+	return start, end, nil
+}
+
+// parseExpr resolves a single expression into the day, week or month it names
+func (p *RangeParser) parseExpr(expr string) (time.Time, time.Time, error) {
+	expr = strings.ToLower(strings.TrimSpace(expr))
+	// Relative expressions count from the current workday, not the calendar day
+	now := WorkdayDate(p.now(), p.DayStart)
+
+	switch expr {
+	case "":
+		return time.Time{}, time.Time{}, fmt.Errorf("empty date expression")
+	case "today", "今天":
+		return p.day(now)
+	case "yesterday", "昨天":
+		return p.day(now.AddDate(0, 0, -1))
+	case "前天":
+		return p.day(now.AddDate(0, 0, -2))
+	case "last-workday", "最近工作日":
+		return p.day(p.previousWorkday(now, true))
+	case "prev-workday", "上个工作日":
+		return p.day(p.previousWorkday(now, false))
+	case "this-week", "本周":
+		return p.week(now)
+	case "last-week", "上周":
+		return p.week(now.AddDate(0, 0, -7))
+	case "this-month", "本月":
+		return p.month(now)
+	case "last-month", "上月":
+		return p.month(time.Date(now.Year(), now.Month()-1, 1, 0, 0, 0, 0, p.Location))
+	}
+
+	if m := daysAgoPattern.FindStringSubmatch(expr); m != nil {
+		n, _ := strconv.Atoi(m[1])
+		return p.day(now.AddDate(0, 0, -n))
+	}
+
+	if name, ok := strings.CutPrefix(expr, "last-"); ok {
+		if wd, ok := weekdays[name]; ok {
+			// Most recent such weekday strictly before today
+			offset := (int(now.Weekday()) - int(wd) + 7) % 7
+			if offset == 0 {
+				offset = 7
+			}
+			return p.day(now.AddDate(0, 0, -offset))
+		}
+	}
+
+	if m := isoWeekPattern.FindStringSubmatch(expr); m != nil {
+		year, _ := strconv.Atoi(m[1])
+		week, _ := strconv.Atoi(m[2])
+		if week < 1 || week > isoWeeksInYear(year) {
+			return time.Time{}, time.Time{}, fmt.Errorf("invalid ISO week %q", expr)
+		}
+		// January 4th is always in week 1
+		jan4 := time.Date(year, time.January, 4, 12, 0, 0, 0, p.Location)
+		return p.week(jan4.AddDate(0, 0, (week-1)*7))
+	}
+
+	if m := monthPattern.FindStringSubmatch(expr); m != nil {
+		year, _ := strconv.Atoi(m[1])
+		month, _ := strconv.Atoi(m[2])
+		if month < 1 || month > 12 {
+			return time.Time{}, time.Time{}, fmt.Errorf("invalid month %q", expr)
+		}
+		return p.month(time.Date(year, time.Month(month), 1, 12, 0, 0, 0, p.Location))
+	}
+
+	date, err := time.ParseInLocation("2006-01-02", expr, p.Location)
+	if err != nil {
+		return time.Time{}, time.Time{}, fmt.Errorf("unsupported date expression %q", expr)
+	}
+	return p.day(date)
+}
+
+// now returns the current time in the parser's location
+func (p *RangeParser) now() time.Time {
+	if p.Now == nil {
+		return time.Now().In(p.Location)
+	}
+	return p.Now().In(p.Location)
+}
+
+// maxWorkdaySearch bounds the search for a workday in calendars without any
+const maxWorkdaySearch = 366
+
+// previousWorkday returns the latest working date before date, or at date when inclusive
+func (p *RangeParser) previousWorkday(date time.Time, inclusive bool) time.Time {
+	if !inclusive {
+		date = date.AddDate(0, 0, -1)
+	}
+	for i := 0; i < maxWorkdaySearch; i++ {
+		if p.isWorkday(date) {
+			return date
+		}
+		date = date.AddDate(0, 0, -1)
+	}
+	return date
+}
+
+// isWorkday consults the calendar, falling back to Monday to Friday
+func (p *RangeParser) isWorkday(date time.Time) bool {
+	if p.Calendar != nil {
+		return p.Calendar.IsWorkday(date)
+	}
+	return date.Weekday() != time.Saturday && date.Weekday() != time.Sunday
+}
+
+// Workdays returns the working dates, at midnight, of the workdays that overlap [start, end]
+func (p *RangeParser) Workdays(start, end time.Time) []time.Time {
+	var days []time.Time
+	last := WorkdayDate(end.In(p.Location), p.DayStart)
+	for day := WorkdayDate(start.In(p.Location), p.DayStart); !day.After(last); day = day.AddDate(0, 0, 1) {
+		if p.isWorkday(day) {
+			days = append(days, day)
+		}
+	}
+	return days
+}
+
+// SplitDays cuts [start, end] at workday boundaries
+func (p *RangeParser) SplitDays(start, end time.Time) []Range {
+	var ranges []Range
+	for start.Before(end) || start.Equal(end) {
+		_, dayEnd := dayRange(WorkdayDate(start.In(p.Location), p.DayStart), p.Location, p.DayStart)
+		if dayEnd.After(end) {
+			dayEnd = end
+		}
+		ranges = append(ranges, Range{Start: start, End: dayEnd})
+		start = dayEnd.Add(time.Nanosecond)
+	}
+	return ranges
+}
+
+// BeforeClock reports whether the current time of day is before the given offset after midnight
+func (p *RangeParser) BeforeClock(clock time.Duration) bool {
+	now := p.now()
+	midnight := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, p.Location)
+	return now.Before(midnight.Add(clock))
+}
+
+// day returns the workday of t's date
+func (p *RangeParser) day(t time.Time) (time.Time, time.Time, error) {
+	start, end := dayRange(t, p.Location, p.DayStart)
+	return start, end, nil
+}
+
+// week returns the ISO week of t's date
+func (p *RangeParser) week(t time.Time) (time.Time, time.Time, error) {
+	start, end := weekRange(t, p.Location, p.DayStart)
+	return start, end, nil
+}
+
+// month returns the calendar month of t's date
+func (p *RangeParser) month(t time.Time) (time.Time, time.Time, error) {
+	start, end := monthRange(t, p.Location, p.DayStart)
+	return start, end, nil
+}
+
+// PeriodRange widens the workday containing t to its day, ISO week or month
+func (p *RangeParser) PeriodRange(period string, t time.Time) (time.Time, time.Time, error) {
+	date := WorkdayDate(t.In(p.Location), p.DayStart)
+	switch period {
+	case "day", "":
+		return p.day(date)
+	case "week":
+		return p.week(date)
+	case "month":
+		return p.month(date)
+	default:
+		return time.Time{}, time.Time{}, fmt.Errorf("unsupported period %q: must be day, week or month", period)
+	}
+}
+
+// isoWeeksInYear returns 53 for years whose December 28th falls in week 53, otherwise 52
+func isoWeeksInYear(year int) int {
+	_, week := time.Date(year, time.December, 28, 0, 0, 0, 0, time.UTC).ISOWeek()
+	return week
+}
+
+// timestampLayouts are the layouts accepted by ParseTimestamp besides RFC3339
+var timestampLayouts = []string{
+	"2006-01-02 15:04:05",
+	"2006-01-02 15:04",
+	"2006-01-02T15:04",
+}
+
+// ParseTimestamp parses RFC3339, "YYYY-MM-DD HH:MM[:SS]" in the parser's
+// location, or "HH:MM" meaning that time today
+func (p *RangeParser) ParseTimestamp(input string) (time.Time, error) {
+	input = strings.TrimSpace(input)
+
+	if t, err := time.Parse(time.RFC3339, input); err == nil {
+		return t.In(p.Location), nil
+	}
+	for _, layout := range timestampLayouts {
+		if t, err := time.ParseInLocation(layout, input, p.Location); err == nil {
+			return t, nil
+		}
+	}
+	if clock, err := time.Parse("15:04", input); err == nil {
+		now := p.now()
+		return time.Date(now.Year(), now.Month(), now.Day(), clock.Hour(), clock.Minute(), 0, 0, p.Location), nil
+	}
+
+	return time.Time{}, fmt.Errorf("unsupported timestamp %q: use RFC3339, YYYY-MM-DD HH:MM or HH:MM", input)
+}
+
+// ParseBounds parses exact range bounds; an empty to means now
+func (p *RangeParser) ParseBounds(from, to string) (time.Time, time.Time, error) {
+	if strings.TrimSpace(from) == "" {
+		return time.Time{}, time.Time{}, fmt.Errorf("range start is required")
+	}
+	start, err := p.ParseTimestamp(from)
+	if err != nil {
+		return time.Time{}, time.Time{}, err
+	}
+
+	end := p.now()
+	if strings.TrimSpace(to) != "" {
+		end, err = p.ParseTimestamp(to)
+		if err != nil {
+			return time.Time{}, time.Time{}, err
+		}
+	}
+
+	if !start.Before(end) {
+		return time.Time{}, time.Time{}, fmt.Errorf("invalid range: from %s must be before to %s",
+			start.Format("2006-01-02 15:04:05"), end.Format("2006-01-02 15:04:05"))
+	}
+	return start, end, nil
+}
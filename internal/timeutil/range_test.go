@@ -0,0 +1,281 @@
+package timeutil
+
+import (
+	"testing"
+	"time"
+)
+
+func fixedParser(t *testing.T) *RangeParser {
+	loc, err := time.LoadLocation("Asia/Shanghai")
+	if err != nil {
+		t.Fatalf("LoadLocation failed: %v", err)
+	}
+	// Monday, 2026-10-19 15:30 in Shanghai
+	now := time.Date(2026, 10, 19, 15, 30, 0, 0, loc)
+	return &RangeParser{Location: loc, Now: func() time.Time { return now }}
+}
+
+func TestRangeParser_Parse(t *testing.T) {
+	tests := []struct {
+		input string
+		start string
+		end   string
+	}{
+		{"today", "2026-10-19 00:00", "2026-10-19 23:59"},
+		{"今天", "2026-10-19 00:00", "2026-10-19 23:59"},
+		{"yesterday", "2026-10-18 00:00", "2026-10-18 23:59"},
+		{"前天", "2026-10-17 00:00", "2026-10-17 23:59"},
+		{"-3d", "2026-10-16 00:00", "2026-10-16 23:59"},
+		{"this-week", "2026-10-19 00:00", "2026-10-25 23:59"},
+		{"本周", "2026-10-19 00:00", "2026-10-25 23:59"},
+		{"last-week", "2026-10-12 00:00", "2026-10-18 23:59"},
+		{"上周", "2026-10-12 00:00", "2026-10-18 23:59"},
+		{"this-month", "2026-10-01 00:00", "2026-10-31 23:59"},
+		{"last-month", "2026-09-01 00:00", "2026-09-30 23:59"},
+		{"last-monday", "2026-10-12 00:00", "2026-10-12 23:59"},
+		{"last-friday", "2026-10-16 00:00", "2026-10-16 23:59"},
+		{"2026-W42", "2026-10-12 00:00", "2026-10-18 23:59"},
+		{"2026-w01", "2025-12-29 00:00", "2026-01-04 23:59"},
+		{"2020-W53", "2020-12-28 00:00", "2021-01-03 23:59"},
+		{"2026-10", "2026-10-01 00:00", "2026-10-31 23:59"},
+		{"2026-02", "2026-02-01 00:00", "2026-02-28 23:59"},
+		{"2026-10-05", "2026-10-05 00:00", "2026-10-05 23:59"},
+		{"2026-10-01,2026-10-03", "2026-10-01 00:00", "2026-10-03 23:59"},
+		{"2026-10-01,", "2026-10-01 00:00", "2026-10-19 15:30"},
+		{"last-week,today", "2026-10-12 00:00", "2026-10-19 23:59"},
+		{" -2d , yesterday ", "2026-10-17 00:00", "2026-10-18 23:59"},
+	}
+
+	p := fixedParser(t)
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			start, end, err := p.Parse(tt.input)
+			if err != nil {
+				t.Fatalf("Parse(%q) failed: %v", tt.input, err)
+			}
+			if got := start.Format("2006-01-02 15:04"); got != tt.start {
+				t.Errorf("start = %s, want %s", got, tt.start)
+			}
+			if got := end.Format("2006-01-02 15:04"); got != tt.end {
+				t.Errorf("end = %s, want %s", got, tt.end)
+			}
+		})
+	}
+}
+
+func TestRangeParser_ParseErrors(t *testing.T) {
+	p := fixedParser(t)
+	for _, input := range []string{"", "someday", "2026-W54", "2026-13", ",2026-10-01", "2026-10-05,2026-10-01", "last-funday"} {
+		if _, _, err := p.Parse(input); err == nil {
+			t.Errorf("Parse(%q) should fail", input)
+		}
+	}
+}
+
+func TestRangeParser_ParseBounds(t *testing.T) {
+	tests := []struct {
+		from  string
+		to    string
+		start string
+		end   string
+	}{
+		{"2026-10-19 09:00", "2026-10-19 12:30", "2026-10-19 09:00", "2026-10-19 12:30"},
+		{"2026-10-18T22:00:00Z", "2026-10-19T02:00:00Z", "2026-10-19 06:00", "2026-10-19 10:00"},
+		{"2026-10-18T20:00", "08:00", "2026-10-18 20:00", "2026-10-19 08:00"},
+		{"09:00", "", "2026-10-19 09:00", "2026-10-19 15:30"},
+	}
+
+	p := fixedParser(t)
+	for _, tt := range tests {
+		t.Run(tt.from+"_"+tt.to, func(t *testing.T) {
+			start, end, err := p.ParseBounds(tt.from, tt.to)
+			if err != nil {
+				t.Fatalf("ParseBounds failed: %v", err)
+			}
+			if got := start.Format("2006-01-02 15:04"); got != tt.start {
+				t.Errorf("start = %s, want %s", got, tt.start)
+			}
+			if got := end.Format("2006-01-02 15:04"); got != tt.end {
+				t.Errorf("end = %s, want %s", got, tt.end)
+			}
+		})
+	}
+}
+
+func TestRangeParser_ParseBoundsErrors(t *testing.T) {
+	p := fixedParser(t)
+	for _, tt := range [][2]string{{"", "12:00"}, {"12:00", "09:00"}, {"12:00", "12:00"}, {"noon", ""}, {"09:00", "2026/10/19"}} {
+		if _, _, err := p.ParseBounds(tt[0], tt[1]); err == nil {
+			t.Errorf("ParseBounds(%q, %q) should fail", tt[0], tt[1])
+		}
+	}
+}
+
+func TestRangeParser_DayStart(t *testing.T) {
+	p := fixedParser(t)
+	p.DayStart = 4 * time.Hour
+	// 01:30 on Tuesday still belongs to Monday's workday
+	now := time.Date(2026, 10, 20, 1, 30, 0, 0, p.Location)
+	p.Now = func() time.Time { return now }
+
+	tests := []struct {
+		input string
+		start string
+		end   string
+	}{
+		{"today", "2026-10-19 04:00", "2026-10-20 03:59"},
+		{"yesterday", "2026-10-18 04:00", "2026-10-19 03:59"},
+		{"2026-10-05", "2026-10-05 04:00", "2026-10-06 03:59"},
+		{"this-week", "2026-10-19 04:00", "2026-10-26 03:59"},
+		{"2026-10", "2026-10-01 04:00", "2026-11-01 03:59"},
+		{"last-sunday", "2026-10-18 04:00", "2026-10-19 03:59"},
+	}
+	for _, tt := range tests {
+		start, end, err := p.Parse(tt.input)
+		if err != nil {
+			t.Fatalf("Parse(%q) failed: %v", tt.input, err)
+		}
+		if got := start.Format("2006-01-02 15:04"); got != tt.start {
+			t.Errorf("Parse(%q) start = %s, want %s", tt.input, got, tt.start)
+		}
+		if got := end.Format("2006-01-02 15:04"); got != tt.end {
+			t.Errorf("Parse(%q) end = %s, want %s", tt.input, got, tt.end)
+		}
+	}
+
+	start, end, err := p.PeriodRange("week", now)
+	if err != nil {
+		t.Fatalf("PeriodRange failed: %v", err)
+	}
+	if start.Format("2006-01-02 15:04") != "2026-10-19 04:00" || end.Format("2006-01-02 15:04") != "2026-10-26 03:59" {
+		t.Errorf("Unexpected week range: %s - %s", start, end)
+	}
+}
+
+func TestRangeParser_PeriodRange(t *testing.T) {
+	p := fixedParser(t)
+	// Sunday 2026-10-18 23:30 in Shanghai
+	at := time.Date(2026, 10, 18, 15, 30, 0, 0, time.UTC)
+
+	tests := []struct {
+		period    string
+		wantStart string
+		wantEnd   string
+	}{
+		{"day", "2026-10-18 00:00:00", "2026-10-18 23:59:59"},
+		{"week", "2026-10-12 00:00:00", "2026-10-18 23:59:59"},
+		{"month", "2026-10-01 00:00:00", "2026-10-31 23:59:59"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.period, func(t *testing.T) {
+			start, end, err := p.PeriodRange(tt.period, at)
+			if err != nil {
+				t.Fatalf("PeriodRange failed: %v", err)
+			}
+			if got := start.Format("2006-01-02 15:04:05"); got != tt.wantStart {
+				t.Errorf("Expected start %s, got %s", tt.wantStart, got)
+			}
+			if got := end.Format("2006-01-02 15:04:05"); got != tt.wantEnd {
+				t.Errorf("Expected end %s, got %s", tt.wantEnd, got)
+			}
+		})
+	}
+
+	if _, _, err := p.PeriodRange("year", at); err == nil {
+		t.Error("Expected error for unsupported period, got nil")
+	}
+}
+
+func TestParseClock(t *testing.T) {
+	if d, err := ParseClock("04:30"); err != nil || d != 4*time.Hour+30*time.Minute {
+		t.Errorf("ParseClock(04:30) = %v, %v", d, err)
+	}
+	if d, err := ParseClock(""); err != nil || d != 0 {
+		t.Errorf("ParseClock(\"\") = %v, %v", d, err)
+	}
+	if _, err := ParseClock("25:00"); err == nil {
+		t.Error("ParseClock(25:00) should fail")
+	}
+}
+
+// holidayCalendar treats weekends and the listed dates as non-working days
+type holidayCalendar map[string]bool
+
+func (c holidayCalendar) IsWorkday(date time.Time) bool {
+	if c[date.Format("2006-01-02")] {
+		return false
+	}
+	return date.Weekday() != time.Saturday && date.Weekday() != time.Sunday
+}
+
+func TestRangeParser_Workdays(t *testing.T) {
+	p := fixedParser(t)
+
+	// Monday: previous workday is Friday, the latest workday is today
+	for input, want := range map[string]string{"prev-workday": "2026-10-16", "上个工作日": "2026-10-16", "last-workday": "2026-10-19"} {
+		start, _, err := p.Parse(input)
+		if err != nil {
+			t.Fatalf("Parse(%q) failed: %v", input, err)
+		}
+		if got := start.Format("2006-01-02"); got != want {
+			t.Errorf("Parse(%q) = %s, want %s", input, got, want)
+		}
+	}
+
+	// Holidays are skipped, and on a weekend last-workday is the Friday before
+	p.Calendar = holidayCalendar{"2026-10-16": true}
+	now := time.Date(2026, 10, 18, 12, 0, 0, 0, p.Location)
+	p.Now = func() time.Time { return now }
+	for input, want := range map[string]string{"prev-workday": "2026-10-15", "last-workday": "2026-10-15"} {
+		start, _, err := p.Parse(input)
+		if err != nil {
+			t.Fatalf("Parse(%q) failed: %v", input, err)
+		}
+		if got := start.Format("2006-01-02"); got != want {
+			t.Errorf("Parse(%q) = %s, want %s", input, got, want)
+		}
+	}
+}
+
+func TestRangeParser_BeforeClock(t *testing.T) {
+	p := fixedParser(t)
+	if !p.BeforeClock(16 * time.Hour) {
+		t.Error("15:30 should be before 16:00")
+	}
+	if p.BeforeClock(10 * time.Hour) {
+		t.Error("15:30 should not be before 10:00")
+	}
+}
+
+func TestRangeParser_SplitDays(t *testing.T) {
+	p := fixedParser(t)
+	start := time.Date(2026, 10, 16, 18, 0, 0, 0, p.Location)
+	end := time.Date(2026, 10, 19, 15, 30, 0, 0, p.Location)
+
+	ranges := p.SplitDays(start, end)
+	want := [][2]string{
+		{"2026-10-16 18:00", "2026-10-16 23:59"},
+		{"2026-10-17 00:00", "2026-10-17 23:59"},
+		{"2026-10-18 00:00", "2026-10-18 23:59"},
+		{"2026-10-19 00:00", "2026-10-19 15:30"},
+	}
+	if len(ranges) != len(want) {
+		t.Fatalf("Expected %d ranges, got %d", len(want), len(ranges))
+	}
+	for i, r := range ranges {
+		if got := [2]string{r.Start.Format("2006-01-02 15:04"), r.End.Format("2006-01-02 15:04")}; got != want[i] {
+			t.Errorf("range %d = %v, want %v", i, got, want[i])
+		}
+	}
+
+	days := p.Workdays(start, end)
+	if len(days) != 2 || days[0].Format("2006-01-02") != "2026-10-16" || days[1].Format("2006-01-02") != "2026-10-19" {
+		t.Errorf("Unexpected workdays: %v", days)
+	}
+
+	p.DayStart = 4 * time.Hour
+	if ranges := p.SplitDays(start, time.Date(2026, 10, 17, 3, 0, 0, 0, p.Location)); len(ranges) != 1 {
+		t.Errorf("Expected the night to stay in the same workday, got %d ranges", len(ranges))
+	}
+}
@@ -1,7 +1,7 @@
 package timeutil
 
 import (
-	"strings"
+	"fmt"
 	"time"
 )
 
@@ -11,29 +11,51 @@ func GetDayRange(t time.Time, timezone string) (time.Time, time.Time, error) {
 	if err != nil {
 		return time.Time{}, time.Time{}, err
 	}
+	start, end := dayRange(t, loc, 0)
+	return start, end, nil
+}
+
+// ParseClock parses a time of day such as "04:00" into its offset after midnight; empty means midnight
+func ParseClock(s string) (time.Duration, error) {
+	if s == "" {
+		return 0, nil
+	}
+	t, err := time.Parse("15:04", s)
+	if err != nil {
+		return 0, fmt.Errorf("invalid time of day %q: use HH:MM", s)
+	}
+	return time.Duration(t.Hour())*time.Hour + time.Duration(t.Minute())*time.Minute, nil
+}
+
+// WorkdayDate returns midnight of the workday containing t in t's location,
+// where workdays begin dayStart after midnight
+func WorkdayDate(t time.Time, dayStart time.Duration) time.Time {
+	shifted := t.Add(-dayStart)
+	return time.Date(shifted.Year(), shifted.Month(), shifted.Day(), 0, 0, 0, 0, t.Location())
+}
 
-	// Convert to local timezone
-	localTime := t.In(loc)
+// dayRange returns dayStart on t's calendar date in loc until dayStart the next day
+func dayRange(t time.Time, loc *time.Location, dayStart time.Duration) (time.Time, time.Time) {
+	t = t.In(loc)
+	date := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, loc)
+	return date.Add(dayStart), date.AddDate(0, 0, 1).Add(dayStart - time.Nanosecond)
+}
 
-	// Start of day: 00:00:00
-	startOfDay := time.Date(
-		localTime.Year(),
-		localTime.Month(),
-		localTime.Day(),
-		0, 0, 0, 0,
-		loc,
-	)
+// weekRange returns the ISO week of t's calendar date in loc, shifted by dayStart
+func weekRange(t time.Time, loc *time.Location, dayStart time.Duration) (time.Time, time.Time) {
+	t = t.In(loc)
 
-	// End of day: 23:59:59
-	endOfDay := time.Date(
-		localTime.Year(),
-		localTime.Month(),
-		localTime.Day(),
-		23, 59, 59, 999999999,
-		loc,
-	)
+	// Monday is the first day of an ISO week
+	offset := (int(t.Weekday()) + 6) % 7
+	monday := time.Date(t.Year(), t.Month(), t.Day()-offset, 0, 0, 0, 0, loc)
+	return monday.Add(dayStart), monday.AddDate(0, 0, 7).Add(dayStart - time.Nanosecond)
+}
 
-	return startOfDay, endOfDay, nil
+// monthRange returns the calendar month of t's date in loc, shifted by dayStart
+func monthRange(t time.Time, loc *time.Location, dayStart time.Duration) (time.Time, time.Time) {
+	t = t.In(loc)
+	first := time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, loc)
+	return first.Add(dayStart), first.AddDate(0, 1, 0).Add(dayStart - time.Nanosecond)
 }
 
 // GetTodayRange returns the start and end time of today
@@ -41,45 +63,12 @@ func GetTodayRange(timezone string) (time.Time, time.Time, error) {
 	return GetDayRange(time.Now(), timezone)
 }
 
-// ParseTimeRange parses custom time range strings
-// Supported formats:
-// - "today": today
-// - "yesterday": yesterday
-// - "2024-02-11": specific day
-// - "2024-02-11,2024-02-12": custom range
+// ParseTimeRange parses a date expression or range relative to the current time.
+// See RangeParser.Parse for the supported expressions.
 func ParseTimeRange(input, timezone string) (time.Time, time.Time, error) {
-	loc, err := time.LoadLocation(timezone)
+	parser, err := NewRangeParser(timezone)
 	if err != nil {
 		return time.Time{}, time.Time{}, err
 	}
-
-	switch input {
-	case "today":
-		return GetDayRange(time.Now(), timezone)
-	case "yesterday":
-		return GetDayRange(time.Now().AddDate(0, 0, -1), timezone)
-	default:
-		// Try to parse as single date or range
-		if strings.Contains(input, ",") {
-			parts := strings.Split(input, ",")
-			if len(parts) == 2 {
-				start, err := time.ParseInLocation("2006-01-02", strings.TrimSpace(parts[0]), loc)
-				if err != nil {
-					return time.Time{}, time.Time{}, err
-				}
-				end, err := time.ParseInLocation("2006-01-02", strings.TrimSpace(parts[1]), loc)
-				if err != nil {
-					return time.Time{}, time.Time{}, err
-				}
-				return start, time.Date(end.Year(), end.Month(), end.Day(), 23, 59, 59, 999999999, loc), nil
-			}
-		}
-
-		// Try to parse as single date
-		date, err := time.ParseInLocation("2006-01-02", input, loc)
-		if err != nil {
-			return time.Time{}, time.Time{}, err
-		}
-		return GetDayRange(date, timezone)
-	}
+	return parser.Parse(input)
 }
@@ -0,0 +1,106 @@
+package worklog
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"daily_report/internal/config"
+)
+
+// jiraTimeLayout is the timestamp format of the Jira worklog API
+const jiraTimeLayout = "2006-01-02T15:04:05.000-0700"
+
+// JiraClient reads and writes issue worklogs through the Jira REST API
+type JiraClient struct {
+	cfg        config.JiraConfig
+	httpClient *http.Client
+}
+
+// NewJiraClient creates a new Jira worklog client
+func NewJiraClient(cfg config.JiraConfig) *JiraClient {
+	return &JiraClient{
+		cfg:        cfg,
+		httpClient: &http.Client{Timeout: 30 * time.Second},
+	}
+}
+
+// Logged reports whether the issue already has a worklog containing the marker
+func (c *JiraClient) Logged(ctx context.Context, issue, marker string) (bool, error) {
+	var result struct {
+		Worklogs []struct {
+			Comment string `json:"comment"`
+		} `json:"worklogs"`
+	}
+	if err := c.do(ctx, http.MethodGet, c.worklogPath(issue), nil, &result); err != nil {
+		return false, err
+	}
+
+	for _, w := range result.Worklogs {
+		if strings.Contains(w.Comment, marker) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// Add posts a worklog for the entry
+func (c *JiraClient) Add(ctx context.Context, entry Entry, marker string) error {
+	body := map[string]interface{}{
+		"started":          entry.Started.Format(jiraTimeLayout),
+		"timeSpentSeconds": int(entry.Duration.Seconds()),
+		"comment":          entry.Comment(marker),
+	}
+	return c.do(ctx, http.MethodPost, c.worklogPath(entry.Issue), body, nil)
+}
+
+// worklogPath returns the worklog collection path of an issue
+func (c *JiraClient) worklogPath(issue string) string {
+	return "/rest/api/2/issue/" + url.PathEscape(issue) + "/worklog"
+}
+
+// do performs an authenticated REST request
+func (c *JiraClient) do(ctx context.Context, method, path string, in, out interface{}) error {
+	var body io.Reader
+	if in != nil {
+		raw, err := json.Marshal(in)
+		if err != nil {
+			return fmt.Errorf("failed to encode request: %w", err)
+		}
+		body = bytes.NewReader(raw)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, strings.TrimRight(c.cfg.URL, "/")+path, body)
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	req.SetBasicAuth(c.cfg.Username, c.cfg.APIToken)
+	req.Header.Set("Accept", "application/json")
+	if in != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	respBody, _ := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("jira returned %s: %s", resp.Status, strings.TrimSpace(string(respBody)))
+	}
+
+	if out != nil {
+		if err := json.Unmarshal(respBody, out); err != nil {
+			return fmt.Errorf("failed to parse jira response: %w", err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,64 @@
+package worklog
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"daily_report/internal/config"
+)
+
+func TestJiraClient_LoggedAndAdd(t *testing.T) {
+	var posted map[string]interface{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if user, pass, _ := r.BasicAuth(); user != "me" || pass != "token" {
+			http.Error(w, "unauthorized", http.StatusUnauthorized)
+			return
+		}
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/rest/api/2/issue/PROJ-1/worklog":
+			w.Write([]byte(`{"worklogs":[{"comment":"manual"},{"comment":"2 个提交\n[daily_report 2026-10-18 me]"}]}`))
+		case r.Method == http.MethodGet && r.URL.Path == "/rest/api/2/issue/PROJ-2/worklog":
+			w.Write([]byte(`{"worklogs":[]}`))
+		case r.Method == http.MethodPost && r.URL.Path == "/rest/api/2/issue/PROJ-2/worklog":
+			json.NewDecoder(r.Body).Decode(&posted)
+			w.WriteHeader(http.StatusCreated)
+			w.Write([]byte(`{"id":"1"}`))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	client := NewJiraClient(config.JiraConfig{URL: server.URL + "/", Username: "me", APIToken: "token"})
+	marker := Marker(time.Date(2026, 10, 18, 0, 0, 0, 0, time.UTC), "me")
+
+	logged, err := client.Logged(context.Background(), "PROJ-1", marker)
+	if err != nil || !logged {
+		t.Errorf("Expected PROJ-1 to be logged, got %v, %v", logged, err)
+	}
+	logged, err = client.Logged(context.Background(), "PROJ-2", marker)
+	if err != nil || logged {
+		t.Errorf("Expected PROJ-2 not to be logged, got %v, %v", logged, err)
+	}
+
+	entry := Entry{
+		Issue:    "PROJ-2",
+		Started:  time.Date(2026, 10, 18, 9, 30, 0, 0, time.FixedZone("CST", 8*3600)),
+		Duration: 90 * time.Minute,
+		Commits:  1,
+	}
+	if err := client.Add(context.Background(), entry, marker); err != nil {
+		t.Fatalf("Add failed: %v", err)
+	}
+	if posted["started"] != "2026-10-18T09:30:00.000+0800" || posted["timeSpentSeconds"] != float64(5400) {
+		t.Errorf("Unexpected worklog payload: %v", posted)
+	}
+
+	if _, err := client.Logged(context.Background(), "MISSING-1", marker); err == nil {
+		t.Error("Expected error for unknown issue, got nil")
+	}
+}
@@ -0,0 +1,185 @@
+package worklog
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"daily_report/internal/config"
+	"daily_report/pkg/models"
+)
+
+// issueKeyPattern matches Jira issue keys such as PROJ-123
+var issueKeyPattern = regexp.MustCompile(`\b[A-Z][A-Z0-9]+-[0-9]+\b`)
+
+// Entry is a proposed worklog for one issue on one day
+type Entry struct {
+	Issue    string
+	Started  time.Time
+	Duration time.Duration
+	Commits  int
+	Meetings int
+	Titles   []string
+	// Logged is set when the entry was already posted by an earlier run
+	Logged bool
+}
+
+// Marker identifies worklogs posted by this tool for an issue, day and user
+func Marker(day time.Time, username string) string {
+	return fmt.Sprintf("[daily_report %s %s]", day.Format("2006-01-02"), username)
+}
+
+// Comment returns the worklog comment including the idempotency marker
+func (e Entry) Comment(marker string) string {
+	var parts []string
+	if e.Commits > 0 {
+		parts = append(parts, fmt.Sprintf("%d 个提交", e.Commits))
+	}
+	if e.Meetings > 0 {
+		parts = append(parts, fmt.Sprintf("%d 场会议", e.Meetings))
+	}
+
+	var sb strings.Builder
+	sb.WriteString(strings.Join(parts, "，"))
+	sb.WriteString("\n")
+	for _, title := range e.Titles {
+		sb.WriteString("- " + title + "\n")
+	}
+	sb.WriteString(marker)
+	return sb.String()
+}
+
+// Propose estimates per-issue worklogs from commits and meetings.
+// A commit is credited with the time since the previous commit, or cfg.FirstCommit
+// after a gap longer than cfg.MaxGap; meetings are credited with their duration.
+// It also returns the number of items that could not be attributed to an issue.
+func Propose(items []models.Item, cfg config.WorklogConfig, projectKey string) ([]Entry, int) {
+	entries := make(map[string]*Entry)
+	unassigned := 0
+
+	credit := func(issue string, start time.Time, d time.Duration, title string) *Entry {
+		e, ok := entries[issue]
+		if !ok {
+			e = &Entry{Issue: issue, Started: start}
+			entries[issue] = e
+		}
+		if start.Before(e.Started) {
+			e.Started = start
+		}
+		e.Duration += d
+		e.Titles = append(e.Titles, title)
+		return e
+	}
+
+	var commits, meetings []models.Item
+	for _, item := range items {
+		switch item.Type {
+		case "git":
+			commits = append(commits, item)
+		case "meeting":
+			meetings = append(meetings, item)
+		}
+	}
+	sort.SliceStable(commits, func(i, j int) bool { return commits[i].Time.Before(commits[j].Time) })
+
+	var prev time.Time
+	for _, commit := range commits {
+		d := cfg.FirstCommit
+		if !prev.IsZero() {
+			if gap := commit.Time.Sub(prev); gap <= cfg.MaxGap {
+				d = gap
+			}
+		}
+		prev = commit.Time
+
+		keys := issueKeys(commit.Title, projectKey)
+		if len(keys) == 0 {
+			unassigned++
+			continue
+		}
+		share := d / time.Duration(len(keys))
+		for _, key := range keys {
+			credit(key, commit.Time.Add(-share), share, commit.Title).Commits++
+		}
+	}
+
+	for _, meeting := range meetings {
+		d := MeetingDuration(meeting)
+		keys := issueKeys(meeting.Title, projectKey)
+		if len(keys) == 0 && cfg.MeetingIssue != "" {
+			keys = []string{cfg.MeetingIssue}
+		}
+		if len(keys) == 0 || d <= 0 {
+			unassigned++
+			continue
+		}
+		share := d / time.Duration(len(keys))
+		for _, key := range keys {
+			credit(key, meeting.Time, share, meeting.Title).Meetings++
+		}
+	}
+
+	result := make([]Entry, 0, len(entries))
+	for _, e := range entries {
+		e.Duration = roundDuration(e.Duration, cfg.Round)
+		result = append(result, *e)
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].Issue < result[j].Issue })
+
+	return result, unassigned
+}
+
+// issueKeys returns the distinct issue keys in a title, limited to a project when given
+func issueKeys(title, projectKey string) []string {
+	var keys []string
+	seen := make(map[string]bool)
+	for _, key := range issueKeyPattern.FindAllString(title, -1) {
+		if projectKey != "" && !strings.HasPrefix(key, projectKey+"-") {
+			continue
+		}
+		if !seen[key] {
+			seen[key] = true
+			keys = append(keys, key)
+		}
+	}
+	return keys
+}
+
+// MeetingDuration reads a meeting's duration from its "duration" (minutes or Go duration)
+// or "end" (RFC3339) metadata
+func MeetingDuration(item models.Item) time.Duration {
+	switch v := item.Metadata["duration"].(type) {
+	case int:
+		return time.Duration(v) * time.Minute
+	case float64:
+		return time.Duration(v * float64(time.Minute))
+	case string:
+		if d, err := time.ParseDuration(v); err == nil {
+			return d
+		}
+		if minutes, err := strconv.Atoi(v); err == nil {
+			return time.Duration(minutes) * time.Minute
+		}
+	}
+	if end, ok := item.Metadata["end"].(string); ok {
+		if t, err := time.Parse(time.RFC3339, end); err == nil {
+			return t.Sub(item.Time)
+		}
+	}
+	return 0
+}
+
+// roundDuration rounds to the nearest unit with a minimum of one unit
+func roundDuration(d, unit time.Duration) time.Duration {
+	if unit <= 0 {
+		return d
+	}
+	rounded := d.Round(unit)
+	if rounded < unit {
+		rounded = unit
+	}
+	return rounded
+}
@@ -0,0 +1,103 @@
+package worklog
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"daily_report/internal/config"
+	"daily_report/pkg/models"
+)
+
+var testWorklogConfig = config.WorklogConfig{
+	FirstCommit: 30 * time.Minute,
+	MaxGap:      2 * time.Hour,
+	Round:       15 * time.Minute,
+}
+
+func commitAt(hour, minute int, title string) models.Item {
+	return models.Item{
+		Type:  "git",
+		Title: title,
+		Time:  time.Date(2026, 10, 18, hour, minute, 0, 0, time.UTC),
+	}
+}
+
+func TestPropose_Commits(t *testing.T) {
+	items := []models.Item{
+		commitAt(11, 0, "PROJ-1 fix login timeout"),
+		commitAt(9, 0, "PROJ-1 start login fix"),
+		commitAt(10, 0, "PROJ-2 export csv"),
+		commitAt(15, 0, "PROJ-2 after lunch"),
+		commitAt(15, 20, "bump deps"),
+	}
+
+	entries, unassigned := Propose(items, testWorklogConfig, "")
+
+	if unassigned != 1 {
+		t.Errorf("Expected 1 unassigned commit, got %d", unassigned)
+	}
+	if len(entries) != 2 {
+		t.Fatalf("Expected 2 entries, got %+v", entries)
+	}
+
+	// PROJ-1: 30m for the first commit + 1h since PROJ-2's commit
+	if entries[0].Issue != "PROJ-1" || entries[0].Duration != 90*time.Minute || entries[0].Commits != 2 {
+		t.Errorf("Unexpected PROJ-1 entry: %+v", entries[0])
+	}
+	if want := time.Date(2026, 10, 18, 8, 30, 0, 0, time.UTC); !entries[0].Started.Equal(want) {
+		t.Errorf("Expected PROJ-1 to start at %v, got %v", want, entries[0].Started)
+	}
+	// PROJ-2: 1h since the first commit + 30m after the 4h gap
+	if entries[1].Issue != "PROJ-2" || entries[1].Duration != 90*time.Minute {
+		t.Errorf("Unexpected PROJ-2 entry: %+v", entries[1])
+	}
+}
+
+func TestPropose_Meetings(t *testing.T) {
+	items := []models.Item{
+		{Type: "meeting", Title: "PROJ-3 design review", Time: time.Date(2026, 10, 18, 14, 0, 0, 0, time.UTC),
+			Metadata: map[string]interface{}{"duration": "1h"}},
+		{Type: "meeting", Title: "站会", Time: time.Date(2026, 10, 18, 10, 0, 0, 0, time.UTC),
+			Metadata: map[string]interface{}{"duration": float64(20)}},
+		{Type: "meeting", Title: "无时长", Time: time.Date(2026, 10, 18, 16, 0, 0, 0, time.UTC)},
+	}
+
+	cfg := testWorklogConfig
+	cfg.MeetingIssue = "OPS-1"
+	entries, unassigned := Propose(items, cfg, "")
+
+	if unassigned != 1 {
+		t.Errorf("Expected the meeting without duration to be unassigned, got %d", unassigned)
+	}
+	if len(entries) != 2 || entries[0].Issue != "OPS-1" || entries[1].Issue != "PROJ-3" {
+		t.Fatalf("Unexpected entries: %+v", entries)
+	}
+	if entries[0].Duration != 15*time.Minute || entries[0].Meetings != 1 {
+		t.Errorf("Expected 20m rounded to 15m, got %+v", entries[0])
+	}
+	if entries[1].Duration != time.Hour {
+		t.Errorf("Expected 1h, got %v", entries[1].Duration)
+	}
+}
+
+func TestPropose_ProjectFilter(t *testing.T) {
+	entries, _ := Propose([]models.Item{commitAt(9, 0, "OTHER-1 and PROJ-7 refactor")}, testWorklogConfig, "PROJ")
+
+	if len(entries) != 1 || entries[0].Issue != "PROJ-7" {
+		t.Errorf("Expected only PROJ-7, got %+v", entries)
+	}
+}
+
+func TestEntry_Comment(t *testing.T) {
+	entry := Entry{Commits: 2, Meetings: 1, Titles: []string{"PROJ-1 a", "PROJ-1 b"}}
+	marker := Marker(time.Date(2026, 10, 18, 0, 0, 0, 0, time.UTC), "me")
+
+	comment := entry.Comment(marker)
+	if !strings.HasSuffix(comment, "[daily_report 2026-10-18 me]") {
+		t.Errorf("Expected marker at the end, got %q", comment)
+	}
+	if !strings.HasPrefix(comment, "2 个提交，1 场会议\n- PROJ-1 a\n") {
+		t.Errorf("Unexpected comment %q", comment)
+	}
+}
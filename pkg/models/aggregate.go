@@ -0,0 +1,37 @@
+package models
+
+import "time"
+
+// Report periods
+const (
+	PeriodDay   = "day"
+	PeriodWeek  = "week"
+	PeriodMonth = "month"
+)
+
+// Aggregate summarizes a multi-day report per day, per repository and by theme
+type Aggregate struct {
+	Days   []DayStats   `json:"days"`
+	Repos  []RepoStats  `json:"repos"`
+	Themes []ThemeStats `json:"themes"`
+}
+
+// DayStats counts items per type on one calendar day
+type DayStats struct {
+	Date  time.Time      `json:"date"`
+	Stats map[string]int `json:"stats"`
+	Total int            `json:"total"`
+}
+
+// RepoStats counts commits in one repository
+type RepoStats struct {
+	Repo       string `json:"repo"`
+	Commits    int    `json:"commits"`
+	ActiveDays int    `json:"active_days"`
+}
+
+// ThemeStats counts items sharing a theme such as a commit scope or Jira issue
+type ThemeStats struct {
+	Theme string `json:"theme"`
+	Count int    `json:"count"`
+}
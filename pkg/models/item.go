@@ -19,6 +19,10 @@ func (it ItemsByTime) Len() int           { return len(it) }
 func (it ItemsByTime) Swap(i, j int)      { it[i], it[j] = it[j], it[i] }
 func (it ItemsByTime) Less(i, j int) bool { return it[i].Time.After(it[j].Time) }
 
+// SchemaVersion is the version of the ReportData JSON schema.
+// Bump it when fields are removed or change meaning.
+const SchemaVersion = "1"
+
 // ReportData contains all collected items grouped by type
 type ReportData struct {
 	Date         time.Time               `json:"date"`
@@ -28,6 +32,18 @@ type ReportData struct {
 	ItemsByType  map[string][]Item       `json:"items_by_type"`
 	Stats        map[string]int          `json:"stats"`
 	SourceStatus map[string]SourceStatus `json:"source_status"`
+	Meta         GenerationMeta          `json:"meta"`
+	Period       string                  `json:"period,omitempty"`    // day (default), week or month
+	Aggregate    *Aggregate              `json:"aggregate,omitempty"` // Set for week and month reports
+}
+
+// GenerationMeta describes when and how a ReportData was produced
+type GenerationMeta struct {
+	SchemaVersion string    `json:"schema_version"`
+	GeneratedAt   time.Time `json:"generated_at"`
+	Generator     string    `json:"generator"`
+	Timezone      string    `json:"timezone,omitempty"`
+	DayStart      string    `json:"day_start,omitempty"` // Workday boundary such as "04:00"; empty means midnight
 }
 
 // SourceStatus represents the collection status of a data source
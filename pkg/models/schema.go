@@ -0,0 +1,8 @@
+package models
+
+import _ "embed"
+
+// ReportDataSchema is the JSON Schema describing the JSON form of ReportData
+//
+//go:embed report_data.schema.json
+var ReportDataSchema []byte